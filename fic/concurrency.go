@@ -0,0 +1,74 @@
+package fic
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+)
+
+// operationLimiter bounds the number of mutating API operations that run at
+// the same time. FIC rejects concurrent operations on a tenant with a 409,
+// so limiting them avoids most of the conflicts that would otherwise
+// only be absorbed by retries. A nil operationLimiter does not limit.
+type operationLimiter chan struct{}
+
+func newOperationLimiter(n int) operationLimiter {
+	if n <= 0 {
+		return nil
+	}
+	return make(operationLimiter, n)
+}
+
+// acquire blocks until an operation slot is free or ctx is done. The returned
+// function releases the slot.
+func (l operationLimiter) acquire(ctx context.Context) (func(), error) {
+	if l == nil {
+		return func() {}, nil
+	}
+
+	select {
+	case l <- struct{}{}:
+		return func() { <-l }, nil
+	case <-ctx.Done():
+		return nil, fmt.Errorf("operation cancelled while waiting for a free operation slot: %w", ctx.Err())
+	}
+}
+
+// limitOperation wraps a Create, Update or Delete function so that it holds
+// an operation slot of the provider for its whole duration, including the
+// wait for the operation to complete. FIC keeps rejecting other operations
+// on the tenant until then, so the slot cannot be released earlier. The time
+// spent waiting for the slot is logged on its own, for the resource type typ.
+func limitOperation(typ, operation string, f func(*schema.ResourceData, interface{}) error) func(*schema.ResourceData, interface{}) error {
+	if f == nil {
+		return nil
+	}
+
+	return func(d *schema.ResourceData, meta interface{}) error {
+		config := meta.(*Config)
+
+		start := time.Now()
+		release, err := config.operations.acquire(config.StopContext())
+		if err != nil {
+			return err
+		}
+		defer release()
+
+		if config.operations != nil {
+			log.Printf("[DEBUG] FIC resource operation slot acquired: resource_type=%s operation=%s id=%q wait=%s",
+				typ, operation, d.Id(), time.Since(start).Round(time.Millisecond))
+		}
+
+		return f(d, meta)
+	}
+}
+
+// limitOperations applies limitOperation to the mutating functions of r.
+func limitOperations(typ string, r *schema.Resource) {
+	r.Create = limitOperation(typ, "create", r.Create)
+	r.Update = limitOperation(typ, "update", r.Update)
+	r.Delete = limitOperation(typ, "delete", r.Delete)
+}
@@ -0,0 +1,109 @@
+package fic
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"log"
+	"os"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+)
+
+func TestLimitOperation(t *testing.T) {
+	const limit = 2
+	config := &Config{operations: newOperationLimiter(limit)}
+
+	var running, peak int32
+	f := limitOperation("fic_eri_port_v1", "create", func(d *schema.ResourceData, meta interface{}) error {
+		n := atomic.AddInt32(&running, 1)
+		for {
+			p := atomic.LoadInt32(&peak)
+			if n <= p || atomic.CompareAndSwapInt32(&peak, p, n) {
+				break
+			}
+		}
+
+		time.Sleep(20 * time.Millisecond)
+		atomic.AddInt32(&running, -1)
+		return nil
+	})
+
+	d := (&schema.Resource{Schema: map[string]*schema.Schema{}}).TestResourceData()
+
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if err := f(d, config); err != nil {
+				t.Errorf("unexpected error: %s", err)
+			}
+		}()
+	}
+	wg.Wait()
+
+	if peak > limit {
+		t.Fatalf("expected at most %d concurrent operations, got %d", limit, peak)
+	}
+	if peak < limit {
+		t.Fatalf("expected operations to run concurrently up to %d, got %d", limit, peak)
+	}
+}
+
+func TestLimitOperationLogsWait(t *testing.T) {
+	var buf bytes.Buffer
+	log.SetOutput(&buf)
+	defer log.SetOutput(os.Stderr)
+
+	config := &Config{operations: newOperationLimiter(1)}
+	f := limitOperation("fic_eri_port_v1", "delete", func(d *schema.ResourceData, meta interface{}) error {
+		return nil
+	})
+
+	d := (&schema.Resource{Schema: map[string]*schema.Schema{}}).TestResourceData()
+	d.SetId("F010123456789")
+	if err := f(d, config); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	expected := `FIC resource operation slot acquired: resource_type=fic_eri_port_v1 operation=delete id="F010123456789" wait=`
+	if !strings.Contains(buf.String(), expected) {
+		t.Fatalf("expected %q in log:\n%s", expected, buf.String())
+	}
+}
+
+func TestOperationLimiterCancelled(t *testing.T) {
+	l := newOperationLimiter(1)
+
+	release, err := l.acquire(context.Background())
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	defer release()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	if _, err := l.acquire(ctx); !errors.Is(err, context.Canceled) {
+		t.Fatalf("expected a context.Canceled error, got %v", err)
+	}
+}
+
+func TestOperationLimiterUnlimited(t *testing.T) {
+	if l := newOperationLimiter(0); l != nil {
+		t.Fatalf("expected no limiter for 0, got capacity %d", cap(l))
+	}
+
+	var l operationLimiter
+	for i := 0; i < 10; i++ {
+		if _, err := l.acquire(context.Background()); err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+	}
+}
@@ -1,6 +1,7 @@
 package fic
 
 import (
+	"context"
 	"crypto/tls"
 	"crypto/x509"
 	"fmt"
@@ -14,39 +15,78 @@ import (
 
 	"log"
 	"net/http"
-	"os"
+	"time"
 
 	"github.com/hashicorp/terraform-plugin-sdk/helper/pathorcontents"
 )
 
 type Config struct {
-	CACertFile        string
-	ClientCertFile    string
-	ClientKeyFile     string
-	Cloud             string
-	DefaultDomain     string
-	DomainID          string
-	DomainName        string
-	EndpointType      string
-	ForceSSSEndpoint  string
-	IdentityEndpoint  string
-	Insecure          *bool
-	Password          string
-	ProjectDomainName string
-	ProjectDomainID   string
-	Region            string
-	TenantID          string
-	TenantName        string
-	Token             string
-	UserDomainName    string
-	UserDomainID      string
-	Username          string
-	UserID            string
-	terraformVersion  string
+	APIVersion          string
+	Backoff             Backoff
+	CACertFile          string
+	ClientCertFile      string
+	ClientKeyFile       string
+	Cloud               string
+	DefaultDomain       string
+	DomainID            string
+	DomainName          string
+	EndpointOverrides   map[string]string
+	EndpointType        string
+	ExposeRawJSON       bool
+	ForceSSSEndpoint    string
+	HTTPTimeout         time.Duration
+	IdentityEndpoint    string
+	Insecure            *bool
+	MaxIdleConns        int
+	Password            string
+	ProjectDomainName   string
+	ProjectDomainID     string
+	RedactHeaders       []string
+	Region              string
+	SkipWaitForActive   bool
+	TenantID            string
+	TenantName          string
+	TLSHandshakeTimeout time.Duration
+	Token               string
+	UserDomainName      string
+	UserDomainID        string
+	Username            string
+	UserID              string
+	terraformVersion    string
+	stopContext         context.Context
+	operations          operationLimiter
 
 	OsClient *fic.ProviderClient
 }
 
+// StopContext returns the context that is cancelled when Terraform asks the
+// provider to stop, e.g. on interrupt. It never returns nil.
+func (c *Config) StopContext() context.Context {
+	if c.stopContext == nil {
+		return context.Background()
+	}
+	return c.stopContext
+}
+
+// newHTTPClient builds the HTTP client used by the FIC ProviderClient.
+// A zero HTTPTimeout or TLSHandshakeTimeout means no timeout.
+func (c *Config) newHTTPClient(tlsConfig *tls.Config, osDebug bool) http.Client {
+	transport := &http.Transport{
+		Proxy:               http.ProxyFromEnvironment,
+		TLSClientConfig:     tlsConfig,
+		TLSHandshakeTimeout: c.TLSHandshakeTimeout,
+		MaxIdleConns:        c.MaxIdleConns,
+	}
+
+	return http.Client{
+		Timeout: c.HTTPTimeout,
+		Transport: &LogRoundTripper{
+			Rt:      transport,
+			OsDebug: osDebug,
+		},
+	}
+}
+
 func (c *Config) LoadAndValidate() error {
 	// Make sure at least one of auth_url or cloud was specified.
 	if c.IdentityEndpoint == "" && c.Cloud == "" {
@@ -71,6 +111,12 @@ func (c *Config) LoadAndValidate() error {
 		return fmt.Errorf("Invalid endpoint type provided")
 	}
 
+	if c.Cloud == "" {
+		if _, err := c.authMethod(); err != nil {
+			return err
+		}
+	}
+
 	clientOpts := new(clientconfig.ClientOpts)
 
 	// If a cloud entry was given, base AuthOptions on a clouds.yaml file.
@@ -132,8 +178,7 @@ func (c *Config) LoadAndValidate() error {
 		return err
 	}
 
-	// Set UserAgent
-	client.UserAgent.Prepend(httpclient.TerraformUserAgent(c.terraformVersion))
+	c.setUserAgent(client)
 
 	config := &tls.Config{}
 	if c.CACertFile != "" {
@@ -172,19 +217,11 @@ func (c *Config) LoadAndValidate() error {
 		config.BuildNameToCertificate()
 	}
 
-	// if OS_DEBUG is set, log the requests and responses
-	var osDebug bool
-	if os.Getenv("OS_DEBUG") != "" {
-		osDebug = true
-	}
+	// Extend the list of headers masked in debug logs
+	AddRedactHeaders(c.RedactHeaders...)
 
-	transport := &http.Transport{Proxy: http.ProxyFromEnvironment, TLSClientConfig: config}
-	client.HTTPClient = http.Client{
-		Transport: &LogRoundTripper{
-			Rt:      transport,
-			OsDebug: osDebug,
-		},
-	}
+	// if OS_DEBUG or FIC_DEBUG is set, log the requests and responses
+	client.HTTPClient = c.newHTTPClient(config, debugEnabled())
 
 	err = utils.Authenticate(client, *ao)
 	if err != nil {
@@ -195,6 +232,43 @@ func (c *Config) LoadAndValidate() error {
 	return nil
 }
 
+// setUserAgent prepends the provider and Terraform versions to the
+// User-Agent of the requests sent by client, so that they can be told apart
+// server-side. go-fic sets the header on each request, before it reaches
+// the logging transport.
+func (c *Config) setUserAgent(client *fic.ProviderClient) {
+	client.UserAgent.Prepend(
+		fmt.Sprintf("terraform-provider-fic/%s (+terraform/%s)", providerVersion, c.terraformVersion),
+		httpclient.TerraformUserAgent(c.terraformVersion),
+	)
+}
+
+// authMethod returns the credential style used to authenticate, "token" or
+// "password". A token takes precedence over a user name and password, as it
+// does in clientconfig.
+func (c *Config) authMethod() (string, error) {
+	if c.Token != "" {
+		return "token", nil
+	}
+
+	hasUser := c.Username != "" || c.UserID != ""
+	switch {
+	case hasUser && c.Password != "":
+		return "password", nil
+	case hasUser:
+		return "", fmt.Errorf("A 'password' must be specified together with 'user_name' or 'user_id' " +
+			"(environment variables OS_PASSWORD or FIC_API_SECRET)")
+	case c.Password != "":
+		return "", fmt.Errorf("A 'user_name' or 'user_id' must be specified together with 'password' " +
+			"(environment variables OS_USERNAME, OS_USER_ID or FIC_API_KEY)")
+	}
+
+	return "", fmt.Errorf("No credentials found: specify either 'token' " +
+		"(environment variables OS_TOKEN, OS_AUTH_TOKEN or FIC_TOKEN), " +
+		"or 'user_name' and 'password' " +
+		"(environment variables OS_USERNAME or FIC_API_KEY, and OS_PASSWORD or FIC_API_SECRET)")
+}
+
 func (c *Config) determineRegion(region string) string {
 	// If a resource-level region was not specified, and a provider-level region was set,
 	// use the provider-level region.
@@ -210,9 +284,26 @@ func (c *Config) getEndpointType() fic.Availability {
 	return fic.AvailabilityPublic
 }
 
+// eriV1Client returns a client of the ERI v1 API. If an API version is
+// configured, go-fic sends it as the microversion of every request.
 func (c *Config) eriV1Client(region string) (*fic.ServiceClient, error) {
-	return utils.NewEriV1(c.OsClient, fic.EndpointOpts{
+	if v, ok := c.EndpointOverrides["eri"]; ok {
+		return &fic.ServiceClient{
+			ProviderClient: c.OsClient,
+			Endpoint:       fic.NormalizeURL(v) + "v1/",
+			Type:           "fic-eri",
+			Microversion:   c.APIVersion,
+		}, nil
+	}
+
+	client, err := utils.NewEriV1(c.OsClient, fic.EndpointOpts{
 		Region:       c.determineRegion(region),
 		Availability: c.getEndpointType(),
 	})
+	if err != nil {
+		return nil, err
+	}
+
+	client.Microversion = c.APIVersion
+	return client, nil
 }
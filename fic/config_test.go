@@ -0,0 +1,160 @@
+package fic
+
+import (
+	"crypto/tls"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/nttcom/go-fic"
+)
+
+func TestConfigNewHTTPClient(t *testing.T) {
+	config := Config{
+		HTTPTimeout:         60 * time.Second,
+		TLSHandshakeTimeout: 5 * time.Second,
+		MaxIdleConns:        10,
+	}
+
+	client := config.newHTTPClient(&tls.Config{}, false)
+	if client.Timeout != config.HTTPTimeout {
+		t.Fatalf("expected timeout %s, got %s", config.HTTPTimeout, client.Timeout)
+	}
+
+	rt, ok := client.Transport.(*LogRoundTripper)
+	if !ok {
+		t.Fatalf("expected a *LogRoundTripper, got %T", client.Transport)
+	}
+
+	transport, ok := rt.Rt.(*http.Transport)
+	if !ok {
+		t.Fatalf("expected a *http.Transport, got %T", rt.Rt)
+	}
+
+	if transport.TLSHandshakeTimeout != config.TLSHandshakeTimeout {
+		t.Fatalf("expected TLS handshake timeout %s, got %s", config.TLSHandshakeTimeout, transport.TLSHandshakeTimeout)
+	}
+
+	if transport.MaxIdleConns != config.MaxIdleConns {
+		t.Fatalf("expected %d max idle connections, got %d", config.MaxIdleConns, transport.MaxIdleConns)
+	}
+}
+
+func TestConfigNewHTTPClientNoTimeout(t *testing.T) {
+	client := (&Config{}).newHTTPClient(&tls.Config{}, false)
+	if client.Timeout != 0 {
+		t.Fatalf("expected no timeout, got %s", client.Timeout)
+	}
+}
+
+func TestConfigEriV1ClientEndpointOverride(t *testing.T) {
+	config := Config{
+		EndpointOverrides: map[string]string{"eri": "http://127.0.0.1:8080"},
+	}
+
+	client, err := config.eriV1Client("")
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	if expected := "http://127.0.0.1:8080/v1/"; client.Endpoint != expected {
+		t.Fatalf("expected endpoint %q, got %q", expected, client.Endpoint)
+	}
+
+	if expected := "http://127.0.0.1:8080/v1/ports"; client.ServiceURL("ports") != expected {
+		t.Fatalf("expected service URL %q, got %q", expected, client.ServiceURL("ports"))
+	}
+}
+
+func TestConfigAuthMethod(t *testing.T) {
+	cases := []struct {
+		config   Config
+		expected string
+		errMatch string
+	}{
+		{Config{Token: "token"}, "token", ""},
+		{Config{Token: "token", Username: "key", Password: "secret"}, "token", ""},
+		{Config{Username: "key", Password: "secret"}, "password", ""},
+		{Config{UserID: "id", Password: "secret"}, "password", ""},
+		{Config{Username: "key"}, "", "FIC_API_SECRET"},
+		{Config{Password: "secret"}, "", "FIC_API_KEY"},
+		{Config{}, "", "FIC_TOKEN"},
+	}
+
+	for i, tc := range cases {
+		method, err := tc.config.authMethod()
+		if tc.errMatch == "" {
+			if err != nil {
+				t.Errorf("case %d: unexpected error: %s", i, err)
+			}
+			if method != tc.expected {
+				t.Errorf("case %d: expected %q, got %q", i, tc.expected, method)
+			}
+			continue
+		}
+
+		if err == nil || !strings.Contains(err.Error(), tc.errMatch) {
+			t.Errorf("case %d: expected an error mentioning %s, got %v", i, tc.errMatch, err)
+		}
+	}
+}
+
+func TestConfigSetUserAgent(t *testing.T) {
+	var userAgent string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		userAgent = r.Header.Get("User-Agent")
+	}))
+	defer server.Close()
+
+	config := Config{terraformVersion: "0.12.29"}
+	client := &fic.ProviderClient{HTTPClient: config.newHTTPClient(&tls.Config{}, true)}
+	config.setUserAgent(client)
+
+	if _, err := client.Request("GET", server.URL, &fic.RequestOpts{OkCodes: []int{200}}); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	expected := "terraform-provider-fic/" + providerVersion + " (+terraform/0.12.29) "
+	if !strings.HasPrefix(userAgent, expected) {
+		t.Fatalf("expected a User-Agent starting with %q, got %q", expected, userAgent)
+	}
+
+	if !strings.HasSuffix(userAgent, fic.DefaultUserAgent) {
+		t.Fatalf("expected a User-Agent ending with %q, got %q", fic.DefaultUserAgent, userAgent)
+	}
+}
+
+func TestConfigAPIVersion(t *testing.T) {
+	var header string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		header = r.Header.Get("OpenStack-API-Version")
+	}))
+	defer server.Close()
+
+	for _, version := range []string{"", "1.0"} {
+		config := Config{
+			APIVersion:        version,
+			EndpointOverrides: map[string]string{"eri": server.URL},
+			OsClient:          &fic.ProviderClient{},
+		}
+
+		client, err := config.eriV1Client("")
+		if err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+
+		if _, err := client.Get(client.ServiceURL("ports"), nil, &fic.RequestOpts{OkCodes: []int{200}}); err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+
+		expected := ""
+		if version != "" {
+			expected = "fic-eri " + version
+		}
+		if header != expected {
+			t.Fatalf("expected OpenStack-API-Version header %q, got %q", expected, header)
+		}
+	}
+}
@@ -0,0 +1,275 @@
+package fic
+
+import (
+	"fmt"
+	"log"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/hashcode"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/validation"
+	"github.com/nttcom/go-fic"
+	"github.com/nttcom/go-fic/pagination"
+	"github.com/unknwon/com"
+
+	portToAWS "github.com/nttcom/go-fic/fic/eri/v1/port_to_aws_connections"
+	portToAzureMicrosoft "github.com/nttcom/go-fic/fic/eri/v1/port_to_azure_microsoft_connections"
+	portToAzurePrivate "github.com/nttcom/go-fic/fic/eri/v1/port_to_azure_private_connections"
+	portToGCP "github.com/nttcom/go-fic/fic/eri/v1/port_to_gcp_connections"
+	portToPort "github.com/nttcom/go-fic/fic/eri/v1/port_to_port_connections"
+	routerToAWS "github.com/nttcom/go-fic/fic/eri/v1/router_paired_to_aws_connections"
+	routerToGCP "github.com/nttcom/go-fic/fic/eri/v1/router_paired_to_gcp_connections"
+	routerToPort "github.com/nttcom/go-fic/fic/eri/v1/router_paired_to_port_connections"
+	routerToAzureMicrosoft "github.com/nttcom/go-fic/fic/eri/v1/router_to_azure_microsoft_connections"
+	routerToAzurePrivate "github.com/nttcom/go-fic/fic/eri/v1/router_to_azure_private_connections"
+	routerToECL "github.com/nttcom/go-fic/fic/eri/v1/router_to_ecl_connections"
+	routerToUNO "github.com/nttcom/go-fic/fic/eri/v1/router_to_uno_connections"
+)
+
+// connectionLister lists the connections of one type. The paired and single
+// router connections to AWS, GCP and ports are listed by the same API, so
+// the paired packages are used for both. path is the collection of the
+// connections in the API.
+type connectionLister struct {
+	connectionType string
+	path           string
+	list           func(*fic.ServiceClient) pagination.Pager
+	extractInto    func(pagination.Page, interface{}) error
+}
+
+var connectionListers = []connectionLister{
+	{
+		"port_to_aws",
+		"port-to-aws-connections",
+		func(c *fic.ServiceClient) pagination.Pager { return portToAWS.List(c, nil) },
+		portToAWS.ExtractConnectionsInto,
+	},
+	{
+		"port_to_azure_microsoft",
+		"port-to-azure-microsoft-connections",
+		func(c *fic.ServiceClient) pagination.Pager { return portToAzureMicrosoft.List(c, nil) },
+		portToAzureMicrosoft.ExtractConnectionsInto,
+	},
+	{
+		"port_to_azure_private",
+		"port-to-azure-private-connections",
+		func(c *fic.ServiceClient) pagination.Pager { return portToAzurePrivate.List(c, nil) },
+		portToAzurePrivate.ExtractConnectionsInto,
+	},
+	{
+		"port_to_gcp",
+		"port-to-gcp-connections",
+		func(c *fic.ServiceClient) pagination.Pager { return portToGCP.List(c, nil) },
+		portToGCP.ExtractConnectionsInto,
+	},
+	{
+		"port_to_port",
+		"port-to-port-connections",
+		func(c *fic.ServiceClient) pagination.Pager { return portToPort.List(c, nil) },
+		portToPort.ExtractConnectionsInto,
+	},
+	{
+		"router_to_aws",
+		"router-to-aws-connections",
+		func(c *fic.ServiceClient) pagination.Pager { return routerToAWS.List(c, nil) },
+		routerToAWS.ExtractConnectionsInto,
+	},
+	{
+		"router_to_azure_microsoft",
+		"router-to-azure-microsoft-connections",
+		func(c *fic.ServiceClient) pagination.Pager { return routerToAzureMicrosoft.List(c, nil) },
+		routerToAzureMicrosoft.ExtractConnectionsInto,
+	},
+	{
+		"router_to_azure_private",
+		"router-to-azure-private-connections",
+		func(c *fic.ServiceClient) pagination.Pager { return routerToAzurePrivate.List(c, nil) },
+		routerToAzurePrivate.ExtractConnectionsInto,
+	},
+	{
+		"router_to_ecl",
+		"router-to-ecl-connections",
+		func(c *fic.ServiceClient) pagination.Pager { return routerToECL.List(c, nil) },
+		routerToECL.ExtractConnectionsInto,
+	},
+	{
+		"router_to_gcp",
+		"router-to-gcp-connections",
+		func(c *fic.ServiceClient) pagination.Pager { return routerToGCP.List(c, nil) },
+		routerToGCP.ExtractConnectionsInto,
+	},
+	{
+		"router_to_port",
+		"router-to-port-connections",
+		func(c *fic.ServiceClient) pagination.Pager { return routerToPort.List(c, nil) },
+		routerToPort.ExtractConnectionsInto,
+	},
+	{
+		"router_to_uno",
+		"router-to-uno-connections",
+		func(c *fic.ServiceClient) pagination.Pager { return routerToUNO.List(c, nil) },
+		routerToUNO.ExtractConnectionsInto,
+	},
+}
+
+// connectionSummary holds the attributes shared by all types of connection.
+// Connections to clouds and ECL have a primary and a secondary connected
+// network address, UNO connections a single one.
+type connectionSummary struct {
+	ID              string `json:"id"`
+	Name            string `json:"name"`
+	Bandwidth       string `json:"bandwidth"`
+	OperationStatus string `json:"operationStatus"`
+	Source          struct {
+		RouterID string `json:"routerId"`
+	} `json:"source"`
+	PrimaryConnectedNetworkAddress   string `json:"primaryConnectedNwAddress"`
+	SecondaryConnectedNetworkAddress string `json:"secondaryConnectedNwAddress"`
+	ConnectedNetworkAddress          string `json:"connectedNwAddress"`
+	ConnectionType                   string `json:"-"`
+	path                             string
+}
+
+func dataSourceEriConnectionsV1() *schema.Resource {
+	connectionTypes := make([]string, 0, len(connectionListers))
+	for _, l := range connectionListers {
+		connectionTypes = append(connectionTypes, l.connectionType)
+	}
+
+	return &schema.Resource{
+		Read: dataSourceEriConnectionsV1Read,
+
+		Schema: map[string]*schema.Schema{
+			"connection_type": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				ValidateFunc: validation.StringInSlice(connectionTypes, false),
+			},
+
+			"operation_status": {
+				Type:          schema.TypeString,
+				Optional:      true,
+				ConflictsWith: []string{"operation_statuses"},
+			},
+
+			"operation_statuses": {
+				Type:          schema.TypeList,
+				Optional:      true,
+				ConflictsWith: []string{"operation_status"},
+				Elem:          &schema.Schema{Type: schema.TypeString},
+			},
+
+			"connections": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"id": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"name": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"connection_type": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"bandwidth": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"operation_status": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+// listConnections returns the connections of the given type, or of all
+// types if connectionType is empty, sorted by ID.
+func listConnections(client *fic.ServiceClient, connectionType string) ([]connectionSummary, error) {
+	var conns []connectionSummary
+	for _, l := range connectionListers {
+		if connectionType != "" && connectionType != l.connectionType {
+			continue
+		}
+
+		err := l.list(client).EachPage(func(page pagination.Page) (bool, error) {
+			var s []connectionSummary
+			if err := l.extractInto(page, &s); err != nil {
+				return false, err
+			}
+
+			for _, c := range s {
+				c.ConnectionType = l.connectionType
+				c.path = l.path
+				conns = append(conns, c)
+			}
+			return true, nil
+		})
+		if err != nil {
+			return nil, fmt.Errorf("unable to retrieve %s connections: %s", l.connectionType, err)
+		}
+	}
+
+	sort.SliceStable(conns, func(i, j int) bool {
+		return conns[i].ID < conns[j].ID
+	})
+
+	return conns, nil
+}
+
+func dataSourceEriConnectionsV1Read(d *schema.ResourceData, meta interface{}) error {
+	config := meta.(*Config)
+	client, err := config.eriV1Client(GetRegion(d, config))
+	if err != nil {
+		return err
+	}
+
+	conns, err := listConnections(client, d.Get("connection_type").(string))
+	if err != nil {
+		return err
+	}
+
+	var statuses []string
+	if v := d.Get("operation_status").(string); v != "" {
+		statuses = append(statuses, v)
+	}
+	for _, v := range d.Get("operation_statuses").([]interface{}) {
+		statuses = append(statuses, v.(string))
+	}
+
+	var ids []string
+	var result []map[string]interface{}
+	for _, c := range conns {
+		if len(statuses) > 0 && !com.IsSliceContainsStr(statuses, c.OperationStatus) {
+			continue
+		}
+
+		ids = append(ids, c.ID)
+		result = append(result, map[string]interface{}{
+			"id":               c.ID,
+			"name":             c.Name,
+			"connection_type":  c.ConnectionType,
+			"bandwidth":        c.Bandwidth,
+			"operation_status": c.OperationStatus,
+		})
+	}
+
+	log.Printf("[DEBUG] Retrieved %d Eri Connections", len(result))
+	d.SetId(strconv.Itoa(hashcode.String(strings.Join(ids, ","))))
+
+	if err := d.Set("connections", result); err != nil {
+		return fmt.Errorf("Error setting connections: %s", err)
+	}
+
+	return nil
+}
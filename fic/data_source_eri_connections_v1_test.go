@@ -0,0 +1,94 @@
+package fic
+
+import (
+	"fmt"
+	"net/http"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+)
+
+func testConnectionListHandler() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var body string
+		switch r.URL.Path + "?" + r.URL.RawQuery {
+		case "/v1/port-to-port-connections?":
+			body = fmt.Sprintf(`{"connections": [
+				{"id": "F030000000003", "name": "p2p_3", "bandwidth": "10M", "operationStatus": "Completed"}
+			], "connections_links": [{"href": "http://%s/v1/port-to-port-connections?page=2", "rel": "next"}]}`, r.Host)
+		case "/v1/port-to-port-connections?page=2":
+			body = `{"connections": [
+				{"id": "F030000000001", "name": "p2p_1", "bandwidth": "100M", "operationStatus": "Error"}
+			]}`
+		case "/v1/router-to-ecl-connections?":
+			body = `{"connections": [
+				{"id": "F030000000002", "name": "ecl_2", "bandwidth": "1G", "operationStatus": "Completed"}
+			]}`
+		default:
+			body = `{"connections": []}`
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		fmt.Fprint(w, body)
+	})
+}
+
+func TestDataSourceEriConnectionsV1Read(t *testing.T) {
+	config, closeServer := testMockConfig(t, testConnectionListHandler())
+	defer closeServer()
+
+	cases := []struct {
+		connectionType    string
+		operationStatus   string
+		operationStatuses []interface{}
+		expected          []string
+	}{
+		{expected: []string{"F030000000001/port_to_port", "F030000000002/router_to_ecl", "F030000000003/port_to_port"}},
+		{connectionType: "port_to_port", expected: []string{"F030000000001/port_to_port", "F030000000003/port_to_port"}},
+		{operationStatus: "Completed", expected: []string{"F030000000002/router_to_ecl", "F030000000003/port_to_port"}},
+		{connectionType: "router_to_uno"},
+		{operationStatuses: []interface{}{"Error", "Processing"}, expected: []string{"F030000000001/port_to_port"}},
+		{operationStatuses: []interface{}{"Completed", "Error"}, expected: []string{"F030000000001/port_to_port", "F030000000002/router_to_ecl", "F030000000003/port_to_port"}},
+		{connectionType: "router_to_ecl", operationStatuses: []interface{}{"Error"}},
+		{operationStatuses: []interface{}{"Processing"}},
+	}
+
+	r := dataSourceEriConnectionsV1()
+	for i, tc := range cases {
+		d := schema.TestResourceDataRaw(t, r.Schema, map[string]interface{}{
+			"connection_type":    tc.connectionType,
+			"operation_status":   tc.operationStatus,
+			"operation_statuses": tc.operationStatuses,
+		})
+
+		if err := dataSourceEriConnectionsV1Read(d, config); err != nil {
+			t.Fatalf("case %d: unexpected error: %s", i, err)
+		}
+
+		var actual []string
+		for _, c := range d.Get("connections").([]interface{}) {
+			m := c.(map[string]interface{})
+			actual = append(actual, m["id"].(string)+"/"+m["connection_type"].(string))
+		}
+
+		if !reflect.DeepEqual(actual, tc.expected) {
+			t.Errorf("case %d: expected connections %v, got %v", i, tc.expected, actual)
+		}
+	}
+}
+
+func TestDataSourceEriConnectionsV1ReadError(t *testing.T) {
+	config, closeServer := testMockConfig(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusInternalServerError)
+	}))
+	defer closeServer()
+
+	r := dataSourceEriConnectionsV1()
+	d := schema.TestResourceDataRaw(t, r.Schema, map[string]interface{}{})
+	err := dataSourceEriConnectionsV1Read(d, config)
+	if err == nil || !strings.Contains(err.Error(), "unable to retrieve port_to_aws connections") {
+		t.Fatalf("expected an error naming the connection type, got %v", err)
+	}
+}
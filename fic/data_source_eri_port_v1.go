@@ -0,0 +1,175 @@
+package fic
+
+import (
+	"fmt"
+	"log"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"github.com/nttcom/go-fic/fic/eri/v1/ports"
+)
+
+func dataSourceEriPortV1() *schema.Resource {
+	return &schema.Resource{
+		Read: dataSourceEriPortV1Read,
+
+		Schema: map[string]*schema.Schema{
+			"name": {
+				Type:     schema.TypeString,
+				Required: true,
+			},
+
+			"location": {
+				Type:     schema.TypeString,
+				Optional: true,
+				Computed: true,
+			},
+
+			"operational_status": {
+				Type:     schema.TypeString,
+				Optional: true,
+				Computed: true,
+			},
+
+			"switch_name": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+
+			"area": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+
+			"port_type": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+
+			"is_activated": {
+				Type:     schema.TypeBool,
+				Computed: true,
+			},
+
+			"tenant_id": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+
+			"vlan_ranges": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"start": {
+							Type:     schema.TypeInt,
+							Computed: true,
+						},
+						"end": {
+							Type:     schema.TypeInt,
+							Computed: true,
+						},
+					},
+				},
+			},
+
+			"vlans": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"vid": {
+							Type:     schema.TypeInt,
+							Computed: true,
+						},
+						"status": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+					},
+				},
+			},
+
+			"uri": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+		},
+	}
+}
+
+func dataSourceEriPortV1Read(d *schema.ResourceData, meta interface{}) error {
+	config := meta.(*Config)
+	client, err := config.eriV1Client(GetRegion(d, config))
+	if err != nil {
+		return err
+	}
+
+	pages, err := ports.List(client, nil).AllPages()
+	if err != nil {
+		return fmt.Errorf("unable to retrieve ports: %s", err)
+	}
+
+	ps, err := ports.ExtractPorts(pages)
+	if err != nil {
+		return fmt.Errorf("unable to extract ports: %s", err)
+	}
+
+	name := d.Get("name").(string)
+	location := d.Get("location").(string)
+	status := d.Get("operational_status").(string)
+
+	var matches []ports.Port
+	for _, p := range ps {
+		if name != p.Name {
+			continue
+		}
+
+		if location != "" && !strings.EqualFold(location, p.Location) {
+			continue
+		}
+
+		if status != "" && status != p.OperationStatus {
+			continue
+		}
+
+		matches = append(matches, p)
+	}
+
+	if len(matches) == 0 {
+		return fmt.Errorf("your query returned no results. Please change your search criteria and try again")
+	}
+
+	if len(matches) >= 2 {
+		candidates := make([]string, 0, len(matches))
+		for _, p := range matches {
+			candidates = append(candidates, fmt.Sprintf("%s (%s, %s)", p.ID, p.Location, p.OperationStatus))
+		}
+		return fmt.Errorf("your query returned more than one result: %s. Please try a more specific search criteria",
+			strings.Join(candidates, ", "))
+	}
+
+	match := matches[0]
+
+	log.Printf("[DEBUG] Retrieved Eri Port %s: %+v", match.ID, match)
+	d.SetId(match.ID)
+
+	d.Set("uri", client.ServiceURL("ports", match.ID))
+	d.Set("name", match.Name)
+	d.Set("location", match.Location)
+	d.Set("operational_status", match.OperationStatus)
+	d.Set("switch_name", match.SwitchName)
+	d.Set("area", match.Area)
+	d.Set("port_type", match.PortType)
+	d.Set("is_activated", match.IsActivated)
+	d.Set("tenant_id", match.TenantID)
+
+	vlanRanges, err := getVLANRangesForState(match.VLANRanges, nil)
+	if err != nil {
+		return err
+	}
+	d.Set("vlan_ranges", vlanRanges)
+	d.Set("vlans", getVLANsForState(&match))
+
+	return nil
+}
@@ -0,0 +1,87 @@
+package fic
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/resource"
+)
+
+func TestAccEriV1PortDataSource_basic(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckEriPortToPortConnectionV1Destroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccEriV1PortDataSourceBasic,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrPair(
+						"data.fic_eri_port_v1.selected", "id",
+						"fic_eri_port_v1.port_1", "id"),
+					resource.TestCheckResourceAttr(
+						"data.fic_eri_port_v1.selected", "port_type", "1G"),
+					resource.TestCheckResourceAttr(
+						"data.fic_eri_port_v1.selected", "switch_name", OS_SWITCH_NAME),
+					resource.TestCheckResourceAttr(
+						"data.fic_eri_port_v1.selected", "vlan_ranges.0.start", "1137"),
+					resource.TestCheckResourceAttr(
+						"data.fic_eri_port_v1.selected", "vlan_ranges.0.end", "1152"),
+					resource.TestCheckResourceAttrSet(
+						"data.fic_eri_port_v1.selected", "tenant_id"),
+					resource.TestCheckResourceAttr(
+						"data.fic_eri_port_v1.selected", "vlans.#", "16"),
+					resource.TestCheckResourceAttr(
+						"data.fic_eri_port_v1.selected", "vlans.0.vid", "1137"),
+					resource.TestCheckResourceAttrPair(
+						"fic_eri_port_to_port_connection_v1.connection_1", "source_port_id",
+						"data.fic_eri_port_v1.selected", "id"),
+				),
+			},
+		},
+	})
+}
+
+var testAccEriV1PortDataSourceBasic = fmt.Sprintf(`
+resource "fic_eri_port_v1" "port_1" {
+	name = "terraform_port_1"
+	switch_name = "%s"
+	port_type = "1G"
+	is_activated = true
+
+	vlan_ranges {
+		start = 1137
+		end = 1152
+	}
+}
+
+resource "fic_eri_port_v1" "port_2" {
+	name = "terraform_port_2"
+	switch_name = "%s"
+	port_type = "1G"
+	is_activated = true
+	depends_on = ["fic_eri_port_v1.port_1"]
+
+	vlan_ranges {
+		start = 1153
+		end = 1168
+	}
+}
+
+data "fic_eri_port_v1" "selected" {
+	name = "${fic_eri_port_v1.port_1.name}"
+	location = "${fic_eri_port_v1.port_1.location}"
+}
+
+resource "fic_eri_port_to_port_connection_v1" "connection_1" {
+	name = "terraform_connection_1"
+	source_port_id = "${data.fic_eri_port_v1.selected.id}"
+	source_vlan = "${data.fic_eri_port_v1.selected.vlan_ranges.0.start}"
+	destination_port_id = "${fic_eri_port_v1.port_2.id}"
+	destination_vlan = "${fic_eri_port_v1.port_2.vlan_ranges.0.start}"
+	bandwidth = "10M"
+}
+`,
+	OS_SWITCH_NAME,
+	OS_SWITCH_NAME,
+)
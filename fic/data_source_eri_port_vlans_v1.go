@@ -0,0 +1,103 @@
+package fic
+
+import (
+	"fmt"
+	"log"
+	"sort"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"github.com/nttcom/go-fic/fic/eri/v1/ports"
+)
+
+func dataSourceEriPortVLANsV1() *schema.Resource {
+	return &schema.Resource{
+		Read: dataSourceEriPortVLANsV1Read,
+
+		Schema: map[string]*schema.Schema{
+			"port_id": {
+				Type:     schema.TypeString,
+				Required: true,
+			},
+
+			"free_vlans": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem:     &schema.Schema{Type: schema.TypeInt},
+			},
+
+			"used_vlans": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem:     &schema.Schema{Type: schema.TypeInt},
+			},
+		},
+	}
+}
+
+// portVLANUsage splits the VLANs of a port into the free and the used ones,
+// both in ascending order. The VLANs of a port are those of its VLAN ranges,
+// which may overlap, and of its VLAN list. A VLAN is used when the port
+// reports it as held by a connection, so that VLANs of connections that are
+// still being created or deleted are not offered.
+func portVLANUsage(p *ports.Port) ([]int, []int, error) {
+	vlanRanges, err := getVLANRangesForState(p.VLANRanges, nil)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	all := make(map[int]bool)
+	for _, vr := range vlanRanges {
+		for vid := vr["start"].(int); vid <= vr["end"].(int); vid++ {
+			all[vid] = true
+		}
+	}
+
+	used := make(map[int]bool)
+	for _, v := range p.VLANs {
+		all[v.VID] = true
+		if v.Status != "unused" {
+			used[v.VID] = true
+		}
+	}
+
+	free := make([]int, 0, len(all))
+	usedVLANs := make([]int, 0, len(used))
+	for vid := range all {
+		if used[vid] {
+			usedVLANs = append(usedVLANs, vid)
+		} else {
+			free = append(free, vid)
+		}
+	}
+	sort.Ints(free)
+	sort.Ints(usedVLANs)
+
+	return free, usedVLANs, nil
+}
+
+func dataSourceEriPortVLANsV1Read(d *schema.ResourceData, meta interface{}) error {
+	config := meta.(*Config)
+	client, err := config.eriV1Client(GetRegion(d, config))
+	if err != nil {
+		return err
+	}
+
+	portID := d.Get("port_id").(string)
+	p, err := ports.Get(client, portID).Extract()
+	if err != nil {
+		return formatFICError(err, fmt.Sprintf("Error retrieving FIC ERI port %s", portID))
+	}
+
+	free, used, err := portVLANUsage(p)
+	if err != nil {
+		return err
+	}
+
+	log.Printf("[DEBUG] Retrieved %d free and %d used VLANs of Eri Port %s", len(free), len(used), p.ID)
+	d.SetId(p.ID)
+
+	d.Set("free_vlans", free)
+	d.Set("used_vlans", used)
+
+	return nil
+}
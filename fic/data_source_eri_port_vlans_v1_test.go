@@ -0,0 +1,90 @@
+package fic
+
+import (
+	"fmt"
+	"net/http"
+	"reflect"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"github.com/nttcom/go-fic/fic/eri/v1/ports"
+)
+
+func TestPortVLANUsage(t *testing.T) {
+	cases := []struct {
+		port ports.Port
+		free []int
+		used []int
+	}{
+		{
+			port: ports.Port{
+				VLANRanges: []string{"105-108", "101-106"},
+				VLANs: []ports.VLAN{
+					{VID: 101, Status: "used"},
+					{VID: 105, Status: "used"},
+					{VID: 105, Status: "used"},
+					{VID: 106, Status: "unused"},
+				},
+			},
+			free: []int{102, 103, 104, 106, 107, 108},
+			used: []int{101, 105},
+		},
+		{
+			port: ports.Port{},
+			free: []int{},
+			used: []int{},
+		},
+	}
+
+	for i, tc := range cases {
+		free, used, err := portVLANUsage(&tc.port)
+		if err != nil {
+			t.Fatalf("case %d: unexpected error: %s", i, err)
+		}
+		if !reflect.DeepEqual(free, tc.free) {
+			t.Errorf("case %d: expected free VLANs %v, got %v", i, tc.free, free)
+		}
+		if !reflect.DeepEqual(used, tc.used) {
+			t.Errorf("case %d: expected used VLANs %v, got %v", i, tc.used, used)
+		}
+	}
+
+	if _, _, err := portVLANUsage(&ports.Port{VLANRanges: []string{"101"}}); err == nil {
+		t.Fatal("expected an error for an invalid VLAN range")
+	}
+}
+
+func TestDataSourceEriPortVLANsV1Read(t *testing.T) {
+	config, closeServer := testMockConfig(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/v1/ports/F010123456789" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		fmt.Fprint(w, `{"port": {"id": "F010123456789", "vlanRanges": ["1137-1140"], "vlans": [
+			{"vid": 1137, "status": "unused"},
+			{"vid": 1138, "status": "used"},
+			{"vid": 1139, "status": "unused"},
+			{"vid": 1140, "status": "used"}
+		]}}`)
+	}))
+	defer closeServer()
+
+	d := schema.TestResourceDataRaw(t, dataSourceEriPortVLANsV1().Schema, map[string]interface{}{
+		"port_id": "F010123456789",
+	})
+	if err := dataSourceEriPortVLANsV1Read(d, config); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	if d.Id() != "F010123456789" {
+		t.Errorf("expected ID F010123456789, got %s", d.Id())
+	}
+	if free := d.Get("free_vlans").([]interface{}); !reflect.DeepEqual(free, []interface{}{1137, 1139}) {
+		t.Errorf("expected free VLANs [1137 1139], got %v", free)
+	}
+	if used := d.Get("used_vlans").([]interface{}); !reflect.DeepEqual(used, []interface{}{1138, 1140}) {
+		t.Errorf("expected used VLANs [1138 1140], got %v", used)
+	}
+}
@@ -0,0 +1,133 @@
+package fic
+
+import (
+	"fmt"
+	"log"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/validation"
+	"github.com/nttcom/go-fic"
+)
+
+func dataSourceEriRouterConnectionV1() *schema.Resource {
+	return &schema.Resource{
+		Read: dataSourceEriRouterConnectionV1Read,
+
+		Schema: map[string]*schema.Schema{
+			"connected_network_address": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ValidateFunc: validation.IsCIDR,
+			},
+
+			"router_id": {
+				Type:     schema.TypeString,
+				Optional: true,
+				Computed: true,
+			},
+
+			"name": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+
+			"bandwidth": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+
+			"operation_status": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+
+			"uri": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+		},
+	}
+}
+
+// listRouterConnections lists the connections of every type of which a router
+// is the source.
+func listRouterConnections(client *fic.ServiceClient) ([]connectionSummary, error) {
+	var conns []connectionSummary
+	for _, l := range connectionListers {
+		if !strings.HasPrefix(l.connectionType, "router_") {
+			continue
+		}
+
+		s, err := listConnections(client, l.connectionType)
+		if err != nil {
+			return nil, err
+		}
+		conns = append(conns, s...)
+	}
+
+	return conns, nil
+}
+
+// connectedNetworkAddresses returns the non-empty connected network addresses
+// of c.
+func connectedNetworkAddresses(c connectionSummary) []string {
+	var addresses []string
+	for _, a := range []string{c.PrimaryConnectedNetworkAddress, c.SecondaryConnectedNetworkAddress, c.ConnectedNetworkAddress} {
+		if a != "" {
+			addresses = append(addresses, a)
+		}
+	}
+	return addresses
+}
+
+func dataSourceEriRouterConnectionV1Read(d *schema.ResourceData, meta interface{}) error {
+	config := meta.(*Config)
+	client, err := config.eriV1Client(GetRegion(d, config))
+	if err != nil {
+		return err
+	}
+
+	conns, err := listRouterConnections(client)
+	if err != nil {
+		return err
+	}
+
+	address := d.Get("connected_network_address").(string)
+	routerID := d.Get("router_id").(string)
+
+	var matches []connectionSummary
+	for _, c := range conns {
+		if routerID != "" && routerID != c.Source.RouterID {
+			continue
+		}
+
+		for _, a := range connectedNetworkAddresses(c) {
+			if a == address {
+				matches = append(matches, c)
+				break
+			}
+		}
+	}
+
+	if len(matches) == 0 {
+		return fmt.Errorf("your query returned no results. Please change your search criteria and try again")
+	}
+
+	if len(matches) >= 2 {
+		return fmt.Errorf("your query returned more than one result. Please try a more specific search criteria")
+	}
+
+	match := matches[0]
+
+	log.Printf("[DEBUG] Retrieved Eri Router Connection %s: %+v", match.ID, match)
+	d.SetId(match.ID)
+
+	d.Set("uri", client.ServiceURL(match.path, match.ID))
+	d.Set("router_id", match.Source.RouterID)
+	d.Set("name", match.Name)
+	d.Set("bandwidth", match.Bandwidth)
+	d.Set("operation_status", match.OperationStatus)
+
+	return nil
+}
@@ -0,0 +1,94 @@
+package fic
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+)
+
+func testRouterConnectionListHandler() http.Handler {
+	lists := map[string]string{
+		"/v1/router-to-aws-connections": `{"connections": [
+			{"id": "F030123456781", "name": "aws", "bandwidth": "100M", "operationStatus": "Completed",
+			 "source": {"routerId": "F020123456789"},
+			 "primaryConnectedNwAddress": "10.0.1.0/30", "secondaryConnectedNwAddress": "10.0.1.4/30"}
+		]}`,
+		"/v1/router-to-ecl-connections": `{"connections": [
+			{"id": "F030123456782", "name": "ecl", "bandwidth": "1G", "operationStatus": "Completed",
+			 "source": {"routerId": "F020123456789"},
+			 "primaryConnectedNwAddress": "10.0.2.0/30", "secondaryConnectedNwAddress": "10.0.2.4/30"},
+			{"id": "F030123456783", "name": "ecl_other_router", "bandwidth": "10M", "operationStatus": "Processing",
+			 "source": {"routerId": "F029876543210"},
+			 "primaryConnectedNwAddress": "10.0.1.0/30", "secondaryConnectedNwAddress": "10.0.1.4/30"}
+		]}`,
+		"/v1/router-to-uno-connections": `{"connections": [
+			{"id": "F030123456784", "name": "uno", "bandwidth": "10M", "operationStatus": "Completed",
+			 "source": {"routerId": "F020123456789"}, "connectedNwAddress": "10.0.4.0/30"}
+		]}`,
+	}
+
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, ok := lists[r.URL.Path]
+		if !ok {
+			body = `{"connections": []}`
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		fmt.Fprint(w, body)
+	})
+}
+
+func TestDataSourceEriRouterConnectionV1Read(t *testing.T) {
+	config, closeServer := testMockConfig(t, testRouterConnectionListHandler())
+	defer closeServer()
+
+	cases := []struct {
+		address  string
+		routerID string
+		id       string
+		uri      string
+		errMatch string
+	}{
+		{address: "10.0.2.4/30", id: "F030123456782", uri: "/v1/router-to-ecl-connections/F030123456782"},
+		{address: "10.0.1.0/30", routerID: "F020123456789", id: "F030123456781", uri: "/v1/router-to-aws-connections/F030123456781"},
+		{address: "10.0.4.0/30", id: "F030123456784", uri: "/v1/router-to-uno-connections/F030123456784"},
+		{address: "10.0.1.4/30", errMatch: "more than one result"},
+		{address: "10.0.3.0/30", errMatch: "no results"},
+	}
+
+	r := dataSourceEriRouterConnectionV1()
+	for i, tc := range cases {
+		d := schema.TestResourceDataRaw(t, r.Schema, map[string]interface{}{
+			"connected_network_address": tc.address,
+			"router_id":                 tc.routerID,
+		})
+
+		err := dataSourceEriRouterConnectionV1Read(d, config)
+		if tc.errMatch != "" {
+			if err == nil || !strings.Contains(err.Error(), tc.errMatch) {
+				t.Errorf("case %d: expected an error containing %q, got %v", i, tc.errMatch, err)
+			}
+			continue
+		}
+
+		if err != nil {
+			t.Errorf("case %d: unexpected error: %s", i, err)
+			continue
+		}
+
+		if d.Id() != tc.id {
+			t.Errorf("case %d: expected connection %s, got %s", i, tc.id, d.Id())
+		}
+
+		if v := d.Get("router_id").(string); v != "F020123456789" {
+			t.Errorf("case %d: expected router_id F020123456789, got %s", i, v)
+		}
+
+		if v := d.Get("uri").(string); v != config.EndpointOverrides["eri"]+tc.uri {
+			t.Errorf("case %d: expected uri %s, got %s", i, config.EndpointOverrides["eri"]+tc.uri, v)
+		}
+	}
+}
@@ -0,0 +1,125 @@
+package fic
+
+import (
+	"fmt"
+	"log"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/validation"
+	"github.com/nttcom/go-fic"
+	"github.com/nttcom/go-fic/pagination"
+
+	connections "github.com/nttcom/go-fic/fic/eri/v1/router_paired_to_port_connections"
+)
+
+func dataSourceEriRouterToPortConnectionV1() *schema.Resource {
+	return &schema.Resource{
+		Read: dataSourceEriRouterToPortConnectionV1Read,
+
+		Schema: map[string]*schema.Schema{
+			"router_id": {
+				Type:     schema.TypeString,
+				Required: true,
+			},
+
+			"vlan": {
+				Type:         schema.TypeInt,
+				Required:     true,
+				ValidateFunc: validation.IntBetween(1, 4094),
+			},
+
+			"name": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+
+			"bandwidth": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+
+			"redundant": {
+				Type:     schema.TypeBool,
+				Computed: true,
+			},
+
+			"operation_status": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+
+			"uri": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+		},
+	}
+}
+
+// findRouterToPortConnection returns the connection from routerID to ports
+// whose primary or secondary destination uses vlan. Paired and single
+// connections are listed by the same API; the secondary destination of a
+// single connection is empty.
+func findRouterToPortConnection(client *fic.ServiceClient, routerID string, vlan int) (*connections.Connection, error) {
+	var matches []connections.Connection
+	err := connections.List(client, nil).EachPage(func(page pagination.Page) (bool, error) {
+		conns, err := connections.ExtractConnections(page)
+		if err != nil {
+			return false, err
+		}
+
+		for _, c := range conns {
+			if c.Source.RouterID != routerID {
+				continue
+			}
+
+			if c.Destination.Primary.VLAN == vlan || c.Destination.Secondary.VLAN == vlan {
+				matches = append(matches, c)
+			}
+		}
+		return true, nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("unable to retrieve router to port connections: %s", err)
+	}
+
+	if len(matches) == 0 {
+		return nil, fmt.Errorf("your query returned no results. Please change your search criteria and try again")
+	}
+
+	if len(matches) >= 2 {
+		candidates := make([]string, 0, len(matches))
+		for _, c := range matches {
+			candidates = append(candidates, fmt.Sprintf("%s (%s, %s)", c.ID, c.Destination.Primary.PortID, c.OperationStatus))
+		}
+		return nil, fmt.Errorf("your query returned more than one result: %s. Please try a more specific search criteria",
+			strings.Join(candidates, ", "))
+	}
+
+	return &matches[0], nil
+}
+
+func dataSourceEriRouterToPortConnectionV1Read(d *schema.ResourceData, meta interface{}) error {
+	config := meta.(*Config)
+	client, err := config.eriV1Client(GetRegion(d, config))
+	if err != nil {
+		return err
+	}
+
+	match, err := findRouterToPortConnection(client, d.Get("router_id").(string), d.Get("vlan").(int))
+	if err != nil {
+		return err
+	}
+
+	log.Printf("[DEBUG] Retrieved Eri Router to Port Connection %s: %+v", match.ID, match)
+	d.SetId(match.ID)
+
+	d.Set("uri", client.ServiceURL("router-to-port-connections", match.ID))
+	d.Set("name", match.Name)
+	d.Set("bandwidth", match.Bandwidth)
+	d.Set("redundant", match.Redundant)
+	d.Set("operation_status", match.OperationStatus)
+
+	return nil
+}
@@ -0,0 +1,77 @@
+package fic
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+)
+
+func TestDataSourceEriRouterToPortConnectionV1Read(t *testing.T) {
+	config, closeServer := testMockConfig(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		fmt.Fprint(w, `{"connections": [
+			{"id": "F030123456781", "name": "paired", "bandwidth": "100M", "redundant": true, "operationStatus": "Completed",
+			 "source": {"routerId": "F020123456789"},
+			 "destination": {"primary": {"portId": "F010123456781", "vlan": 101}, "secondary": {"portId": "F010123456782", "vlan": 102}}},
+			{"id": "F030123456782", "name": "single", "bandwidth": "10M", "redundant": false, "operationStatus": "Processing",
+			 "source": {"routerId": "F020123456789"},
+			 "destination": {"primary": {"portId": "F010123456783", "vlan": 103}}},
+			{"id": "F030123456783", "name": "other_port", "bandwidth": "10M", "operationStatus": "Completed",
+			 "source": {"routerId": "F020123456789"},
+			 "destination": {"primary": {"portId": "F010123456784", "vlan": 103}}},
+			{"id": "F030123456784", "name": "other_router", "bandwidth": "1G", "operationStatus": "Completed",
+			 "source": {"routerId": "F029876543210"},
+			 "destination": {"primary": {"portId": "F010123456785", "vlan": 101}}}
+		]}`)
+	}))
+	defer closeServer()
+
+	cases := []struct {
+		vlan      int
+		id        string
+		bandwidth string
+		redundant bool
+		errMatch  string
+	}{
+		{vlan: 102, id: "F030123456781", bandwidth: "100M", redundant: true},
+		{vlan: 103, errMatch: "more than one result: F030123456782 (F010123456783, Processing), F030123456783"},
+		{vlan: 104, errMatch: "no results"},
+	}
+
+	r := dataSourceEriRouterToPortConnectionV1()
+	for i, tc := range cases {
+		d := schema.TestResourceDataRaw(t, r.Schema, map[string]interface{}{
+			"router_id": "F020123456789",
+			"vlan":      tc.vlan,
+		})
+
+		err := dataSourceEriRouterToPortConnectionV1Read(d, config)
+		if tc.errMatch != "" {
+			if err == nil || !strings.Contains(err.Error(), tc.errMatch) {
+				t.Errorf("case %d: expected an error containing %q, got %v", i, tc.errMatch, err)
+			}
+			continue
+		}
+
+		if err != nil {
+			t.Errorf("case %d: unexpected error: %s", i, err)
+			continue
+		}
+
+		if d.Id() != tc.id {
+			t.Errorf("case %d: expected ID %s, got %s", i, tc.id, d.Id())
+		}
+		if v := d.Get("bandwidth").(string); v != tc.bandwidth {
+			t.Errorf("case %d: expected bandwidth %s, got %s", i, tc.bandwidth, v)
+		}
+		if v := d.Get("redundant").(bool); v != tc.redundant {
+			t.Errorf("case %d: expected redundant %t, got %t", i, tc.redundant, v)
+		}
+		if v := d.Get("uri").(string); v != config.EndpointOverrides["eri"]+"/v1/router-to-port-connections/"+tc.id {
+			t.Errorf("case %d: unexpected uri %s", i, v)
+		}
+	}
+}
@@ -0,0 +1,189 @@
+package fic
+
+import (
+	"fmt"
+	"log"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"github.com/nttcom/go-fic/fic/eri/v1/routers"
+)
+
+func dataSourceEriRouterV1() *schema.Resource {
+	return &schema.Resource{
+		Read: dataSourceEriRouterV1Read,
+
+		Schema: map[string]*schema.Schema{
+			"id": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Computed:     true,
+				ExactlyOneOf: []string{"id", "name"},
+			},
+
+			"name": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Computed:     true,
+				ExactlyOneOf: []string{"id", "name"},
+			},
+
+			"area": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+
+			"user_ip_address": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+
+			"redundant": {
+				Type:     schema.TypeBool,
+				Computed: true,
+			},
+
+			"tenant_id": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+
+			"operational_status": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+
+			"firewalls": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"id": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"is_activated": {
+							Type:     schema.TypeBool,
+							Computed: true,
+						},
+					},
+				},
+			},
+
+			"nats": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"id": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"is_activated": {
+							Type:     schema.TypeBool,
+							Computed: true,
+						},
+					},
+				},
+			},
+
+			"routing_groups": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"name": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+					},
+				},
+			},
+
+			"firewall_id": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+
+			"nat_id": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+
+			"uri": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+		},
+	}
+}
+
+func dataSourceEriRouterV1Read(d *schema.ResourceData, meta interface{}) error {
+	config := meta.(*Config)
+	client, err := config.eriV1Client(GetRegion(d, config))
+	if err != nil {
+		return err
+	}
+
+	var match *routers.Router
+	if v, ok := d.GetOk("id"); ok {
+		match, err = routers.Get(client, v.(string)).Extract()
+		if err != nil {
+			return fmt.Errorf("unable to retrieve router %s: %s", v.(string), err)
+		}
+	} else {
+		pages, err := routers.List(client, nil).AllPages()
+		if err != nil {
+			return fmt.Errorf("unable to retrieve routers: %s", err)
+		}
+
+		rs, err := routers.ExtractRouters(pages)
+		if err != nil {
+			return fmt.Errorf("unable to extract routers: %s", err)
+		}
+
+		name := d.Get("name").(string)
+
+		var matches []routers.Router
+		for _, r := range rs {
+			if name == r.Name {
+				matches = append(matches, r)
+			}
+		}
+
+		if len(matches) == 0 {
+			return fmt.Errorf("your query returned no results. Please change your search criteria and try again")
+		}
+
+		if len(matches) >= 2 {
+			return fmt.Errorf("your query returned more than one result. Please try a more specific search criteria")
+		}
+
+		match = &matches[0]
+	}
+
+	// A router in "Processing" state is still returned, so that callers can
+	// inspect operational_status instead of failing the read.
+	log.Printf("[DEBUG] Retrieved Eri Router %s: %+v", match.ID, match)
+	d.SetId(match.ID)
+
+	d.Set("uri", client.ServiceURL("routers", match.ID))
+	d.Set("name", match.Name)
+	d.Set("area", match.Area)
+	d.Set("user_ip_address", match.UserIPAddress)
+	d.Set("redundant", match.Redundant)
+	d.Set("tenant_id", match.TenantID)
+	d.Set("operational_status", match.OperationStatus)
+	d.Set("firewalls", getRouterFirewallForState(match))
+	d.Set("nats", getRouterNATForState(match))
+	d.Set("routing_groups", getRoutingGroupForState(match))
+
+	if len(match.Firewalls) > 0 {
+		d.Set("firewall_id", match.Firewalls[0].ID)
+	}
+
+	if len(match.NATs) > 0 {
+		d.Set("nat_id", match.NATs[0].ID)
+	}
+
+	return nil
+}
@@ -0,0 +1,90 @@
+package fic
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/resource"
+)
+
+func TestAccEriV1RouterDataSource_basic(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckEriRouterSingleToPortConnectionV1Destroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccEriV1RouterDataSourceBasic,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrPair(
+						"data.fic_eri_router_v1.selected", "id",
+						"fic_eri_router_v1.router_1", "id"),
+					resource.TestCheckResourceAttr(
+						"data.fic_eri_router_v1.selected", "area", OS_AREA_NAME),
+					resource.TestCheckResourceAttr(
+						"data.fic_eri_router_v1.selected", "user_ip_address", "10.0.0.0/27"),
+					resource.TestCheckResourceAttr(
+						"data.fic_eri_router_v1.selected", "redundant", "false"),
+					resource.TestCheckResourceAttrSet(
+						"data.fic_eri_router_v1.selected", "operational_status"),
+					resource.TestCheckResourceAttrSet(
+						"data.fic_eri_router_v1.selected", "firewall_id"),
+					resource.TestCheckResourceAttrPair(
+						"fic_eri_router_single_to_port_connection_v1.connection_1", "source_router_id",
+						"data.fic_eri_router_v1.selected", "id"),
+				),
+			},
+		},
+	})
+}
+
+var testAccEriV1RouterDataSourceBasic = fmt.Sprintf(`
+resource "fic_eri_router_v1" "router_1" {
+	name = "terraform_router_1"
+	area = "%s"
+	user_ip_address = "10.0.0.0/27"
+	redundant = false
+}
+
+data "fic_eri_router_v1" "selected" {
+	name = "${fic_eri_router_v1.router_1.name}"
+}
+
+resource "fic_eri_port_v1" "port_1" {
+	name = "terraform_port_1"
+	switch_name = "%s"
+	port_type = "10G"
+
+	vlan_ranges {
+		start = 1137
+		end = 1152
+	}
+}
+
+resource "fic_eri_router_single_to_port_connection_v1" "connection_1" {
+	name = "terraform_connection_1"
+	source_router_id = "${data.fic_eri_router_v1.selected.id}"
+	source_group_name = "group_1"
+
+	source_information {
+		ip_address = "10.0.1.1/30"
+		as_path_prepend_in = "4"
+		as_path_prepend_out = "4"
+	}
+
+	source_route_filter_in = "fullRoute"
+	source_route_filter_out = "fullRouteWithDefaultRoute"
+
+	destination_information {
+		port_id = "${fic_eri_port_v1.port_1.id}"
+		vlan = "${fic_eri_port_v1.port_1.vlan_ranges.0.start}"
+		ip_address = "10.0.1.2/30"
+		asn = "65000"
+	}
+
+	bandwidth = "10M"
+}
+`,
+	OS_AREA_NAME,
+	OS_SWITCH_NAME,
+)
@@ -62,6 +62,11 @@ func dataSourceEriSwitchV1() *schema.Resource {
 					},
 				},
 			},
+
+			"uri": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
 		},
 	}
 }
@@ -110,11 +115,11 @@ func dataSourceEriSwitchV1Read(d *schema.ResourceData, meta interface{}) error {
 			continue
 		}
 
-		if opts.area != "" && opts.area != sw.Area {
+		if opts.area != "" && !strings.EqualFold(opts.area, sw.Area) {
 			continue
 		}
 
-		if opts.location != "" && opts.location != sw.Location {
+		if opts.location != "" && !strings.EqualFold(opts.location, sw.Location) {
 			continue
 		}
 
@@ -139,6 +144,7 @@ func dataSourceEriSwitchV1Read(d *schema.ResourceData, meta interface{}) error {
 	log.Printf("[DEBUG] Retrieved Eri Switch %s: %+v", match.ID, match)
 	d.SetId(match.ID)
 
+	d.Set("uri", client.ServiceURL("switches", match.ID))
 	d.Set("name", match.SwitchName)
 	d.Set("area", match.Area)
 	d.Set("location", match.Location)
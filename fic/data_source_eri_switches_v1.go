@@ -0,0 +1,94 @@
+package fic
+
+import (
+	"fmt"
+	"log"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/hashcode"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/validation"
+	"github.com/nttcom/go-fic/fic/eri/v1/switches"
+)
+
+func dataSourceEriSwitchesV1() *schema.Resource {
+	return &schema.Resource{
+		Read: dataSourceEriSwitchesV1Read,
+
+		Schema: map[string]*schema.Schema{
+			"location": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+
+			"port_type": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				ValidateFunc: validation.StringInSlice([]string{"1G", "10G"}, false),
+			},
+
+			"names": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+			},
+		},
+	}
+}
+
+func dataSourceEriSwitchesV1Read(d *schema.ResourceData, meta interface{}) error {
+	config := meta.(*Config)
+	client, err := config.eriV1Client(GetRegion(d, config))
+	if err != nil {
+		return err
+	}
+
+	pages, err := switches.List(client, nil).AllPages()
+	if err != nil {
+		return fmt.Errorf("unable to retrieve switches: %s", err)
+	}
+
+	sws, err := switches.ExtractSwitches(pages)
+	if err != nil {
+		return fmt.Errorf("unable to extract switches: %s", err)
+	}
+
+	location := d.Get("location").(string)
+	portType := d.Get("port_type").(string)
+
+	names := make([]string, 0, len(sws))
+	for _, sw := range sws {
+		if location != "" && !strings.EqualFold(location, sw.Location) {
+			continue
+		}
+
+		if portType != "" && !switchHasPortType(sw, portType) {
+			continue
+		}
+
+		names = append(names, sw.SwitchName)
+	}
+	sort.Strings(names)
+
+	log.Printf("[DEBUG] Retrieved %d Eri Switches", len(names))
+	d.SetId(strconv.Itoa(hashcode.String(location + "/" + portType + "/" + strings.Join(names, ","))))
+
+	if err := d.Set("names", names); err != nil {
+		return fmt.Errorf("Error setting names: %s", err)
+	}
+
+	return nil
+}
+
+// switchHasPortType reports whether ports of the given type can be created
+// on the switch.
+func switchHasPortType(sw switches.Switch, portType string) bool {
+	for _, pt := range sw.PortTypes {
+		if pt.Available && pt.Type == portType {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,52 @@
+package fic
+
+import (
+	"fmt"
+	"net/http"
+	"reflect"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+)
+
+func TestDataSourceEriSwitchesV1Read(t *testing.T) {
+	config, closeServer := testMockConfig(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		fmt.Fprint(w, `{"switches": [
+			{"id": "SW3", "switchName": "lxea03comnw1", "location": "NTTComTokyo(NW1)",
+			 "portTypes": [{"portType": "1G", "available": true}, {"portType": "10G", "available": true}]},
+			{"id": "SW1", "switchName": "lxea01comnw1", "location": "NTTComTokyo(NW1)",
+			 "portTypes": [{"portType": "1G", "available": true}, {"portType": "10G", "available": false}]},
+			{"id": "SW2", "switchName": "lxwa01comnw1", "location": "NTTComOsaka(NW1)",
+			 "portTypes": [{"portType": "1G", "available": true}]}
+		]}`)
+	}))
+	defer closeServer()
+
+	cases := []struct {
+		location string
+		portType string
+		expected []interface{}
+	}{
+		{expected: []interface{}{"lxea01comnw1", "lxea03comnw1", "lxwa01comnw1"}},
+		{location: "nttcomtokyo(nw1)", expected: []interface{}{"lxea01comnw1", "lxea03comnw1"}},
+		{location: "NTTComTokyo(NW1)", portType: "10G", expected: []interface{}{"lxea03comnw1"}},
+		{location: "NTTComOsaka(NW1)", portType: "10G", expected: []interface{}{}},
+	}
+
+	r := dataSourceEriSwitchesV1()
+	for i, tc := range cases {
+		d := schema.TestResourceDataRaw(t, r.Schema, map[string]interface{}{
+			"location":  tc.location,
+			"port_type": tc.portType,
+		})
+
+		if err := dataSourceEriSwitchesV1Read(d, config); err != nil {
+			t.Fatalf("case %d: unexpected error: %s", i, err)
+		}
+
+		if actual := d.Get("names").([]interface{}); !reflect.DeepEqual(actual, tc.expected) {
+			t.Errorf("case %d: expected names %v, got %v", i, tc.expected, actual)
+		}
+	}
+}
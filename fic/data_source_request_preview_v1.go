@@ -0,0 +1,171 @@
+package fic
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"log"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/hashcode"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/validation"
+	"github.com/hashicorp/terraform-plugin-sdk/terraform"
+	"github.com/nttcom/go-fic"
+)
+
+// requestPreviewEndpoint is the ERI endpoint of the client used to preview
+// requests. Requests to it never leave requestRecorder.
+const requestPreviewEndpoint = "https://request-preview.invalid"
+
+var errRequestPreviewed = errors.New("request not sent: previewing the request")
+
+// requestRecorder is an http.RoundTripper that records the first request
+// that is not a GET, and fails every request without sending it.
+type requestRecorder struct {
+	method string
+	path   string
+	body   []byte
+}
+
+func (r *requestRecorder) RoundTrip(req *http.Request) (*http.Response, error) {
+	if r.method == "" && req.Method != http.MethodGet {
+		r.method = req.Method
+		r.path = req.URL.Path
+		if req.Body != nil {
+			b, err := ioutil.ReadAll(req.Body)
+			if err != nil {
+				return nil, err
+			}
+			r.body = b
+		}
+	}
+
+	if req.Body != nil {
+		req.Body.Close()
+	}
+	return nil, errRequestPreviewed
+}
+
+func dataSourceRequestPreviewV1() *schema.Resource {
+	return &schema.Resource{
+		Read: dataSourceRequestPreviewV1Read,
+
+		Schema: map[string]*schema.Schema{
+			"resource_type": {
+				Type:     schema.TypeString,
+				Required: true,
+			},
+
+			"config_json": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ValidateFunc: validation.ValidateJsonString,
+			},
+
+			"method": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+
+			"path": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+
+			"request_body": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+		},
+	}
+}
+
+// previewCreateRequest runs the Create function of the resource r configured
+// with raw against a client that sends no request, and returns the recorded
+// request that would create the resource. Resources reading from the API
+// before creating fail to preview.
+func previewCreateRequest(r *schema.Resource, raw map[string]interface{}, apiVersion string) (*requestRecorder, error) {
+	rc := terraform.NewResourceConfigRaw(raw)
+	if _, errs := r.Validate(rc); len(errs) > 0 {
+		msgs := make([]string, 0, len(errs))
+		for _, err := range errs {
+			msgs = append(msgs, err.Error())
+		}
+		return nil, fmt.Errorf("invalid configuration: %s", strings.Join(msgs, "; "))
+	}
+
+	recorder := &requestRecorder{}
+	config := &Config{
+		APIVersion:        apiVersion,
+		Backoff:           Backoff{Disabled: true},
+		EndpointOverrides: map[string]string{"eri": requestPreviewEndpoint},
+		OsClient: &fic.ProviderClient{
+			HTTPClient: http.Client{Transport: recorder},
+		},
+	}
+
+	// Without a configured provider, CustomizeDiff skips its checks against
+	// the API.
+	diff, err := r.Diff(nil, rc, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	if _, err := r.Apply(nil, diff, config); err != nil && recorder.method == "" {
+		return nil, fmt.Errorf("no request was built: %s", err)
+	}
+
+	if recorder.method == "" {
+		return nil, fmt.Errorf("no request was built")
+	}
+
+	return recorder, nil
+}
+
+func dataSourceRequestPreviewV1Read(d *schema.ResourceData, meta interface{}) error {
+	config := meta.(*Config)
+	resourceType := d.Get("resource_type").(string)
+
+	r, ok := Provider().(*schema.Provider).ResourcesMap[resourceType]
+	if !ok {
+		return fmt.Errorf("unknown resource type %q", resourceType)
+	}
+
+	var raw map[string]interface{}
+	if err := json.Unmarshal([]byte(d.Get("config_json").(string)), &raw); err != nil {
+		return fmt.Errorf("Error parsing config_json: %s", err)
+	}
+
+	req, err := previewCreateRequest(r, raw, config.APIVersion)
+	if err != nil {
+		return fmt.Errorf("unable to preview the request creating %s: %s", resourceType, err)
+	}
+
+	body := ""
+	if len(req.body) > 0 {
+		var doc interface{}
+		if err := json.Unmarshal(req.body, &doc); err != nil {
+			return fmt.Errorf("Error parsing the request body: %s", err)
+		}
+		scrubRawJSON(doc)
+
+		b, err := json.Marshal(doc)
+		if err != nil {
+			return fmt.Errorf("Error marshaling the request body: %s", err)
+		}
+		body = string(b)
+	}
+
+	log.Printf("[DEBUG] Previewed request creating %s: %s %s", resourceType, req.method, req.path)
+	d.SetId(strconv.Itoa(hashcode.String(req.method + " " + req.path + " " + body)))
+
+	d.Set("method", req.method)
+	d.Set("path", req.path)
+	d.Set("request_body", body)
+
+	return nil
+}
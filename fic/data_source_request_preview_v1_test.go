@@ -0,0 +1,89 @@
+package fic
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+)
+
+func TestDataSourceRequestPreviewV1Read(t *testing.T) {
+	raw := testPortToPortConnectionV1RawConfig("F010123456789", 1137, "F019876543210", 1138)
+
+	var sent []byte
+	config, closeServer := testMockConfig(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodPost {
+			sent, _ = ioutil.ReadAll(r.Body)
+			w.WriteHeader(http.StatusInternalServerError)
+		}
+	}))
+	defer closeServer()
+
+	d := schema.TestResourceDataRaw(t, resourceEriPortToPortConnectionV1().Schema, raw)
+	resourceEriPortToPortConnectionV1Create(d, config)
+	if sent == nil {
+		t.Fatal("expected Create to send a request")
+	}
+
+	configJSON, err := json.Marshal(raw)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	pd := schema.TestResourceDataRaw(t, dataSourceRequestPreviewV1().Schema, map[string]interface{}{
+		"resource_type": "fic_eri_port_to_port_connection_v1",
+		"config_json":   string(configJSON),
+	})
+
+	if err := dataSourceRequestPreviewV1Read(pd, &Config{}); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	if v := pd.Get("method").(string); v != http.MethodPost {
+		t.Fatalf("expected method POST, got %s", v)
+	}
+
+	if v := pd.Get("path").(string); v != "/v1/port-to-port-connections" {
+		t.Fatalf("expected path /v1/port-to-port-connections, got %s", v)
+	}
+
+	var previewed, actual interface{}
+	if err := json.Unmarshal([]byte(pd.Get("request_body").(string)), &previewed); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if err := json.Unmarshal(sent, &actual); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	if !reflect.DeepEqual(previewed, actual) {
+		t.Fatalf("expected the previewed body to match the sent body\npreviewed: %v\nsent:      %v", previewed, actual)
+	}
+}
+
+func TestDataSourceRequestPreviewV1ReadErrors(t *testing.T) {
+	cases := []struct {
+		resourceType string
+		configJSON   string
+		errMatch     string
+	}{
+		{"fic_eri_unknown_v1", `{}`, `unknown resource type "fic_eri_unknown_v1"`},
+		{"fic_eri_port_to_port_connection_v1", `{"name": "terraform_connection_1"}`, "invalid configuration"},
+	}
+
+	r := dataSourceRequestPreviewV1()
+	for i, tc := range cases {
+		d := schema.TestResourceDataRaw(t, r.Schema, map[string]interface{}{
+			"resource_type": tc.resourceType,
+			"config_json":   tc.configJSON,
+		})
+
+		err := dataSourceRequestPreviewV1Read(d, &Config{})
+		if err == nil || !strings.Contains(err.Error(), tc.errMatch) {
+			t.Errorf("case %d: expected an error containing %q, got %v", i, tc.errMatch, err)
+		}
+	}
+}
@@ -0,0 +1,202 @@
+package fic
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"log"
+	"net/http"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+)
+
+// maxLoggedBodySize is the number of bytes of a request or response body
+// written to the debug log. Longer bodies are truncated.
+const maxLoggedBodySize = 4096
+
+// debugEnabled reports whether HTTP debug logging was requested, either by a
+// non-empty OS_DEBUG or by a truthy FIC_DEBUG environment variable.
+func debugEnabled() bool {
+	if os.Getenv("OS_DEBUG") != "" {
+		return true
+	}
+
+	v, err := strconv.ParseBool(os.Getenv("FIC_DEBUG"))
+	return err == nil && v
+}
+
+// truncateBody shortens a body to maxLoggedBodySize for logging.
+func truncateBody(body string) string {
+	if len(body) <= maxLoggedBodySize {
+		return body
+	}
+
+	return fmt.Sprintf("%s... (%d bytes truncated)", body[:maxLoggedBodySize], len(body)-maxLoggedBodySize)
+}
+
+// LogRoundTripper satisfies the http.RoundTripper interface and is used to
+// customize the default http client RoundTripper to allow for logging.
+type LogRoundTripper struct {
+	Rt      http.RoundTripper
+	OsDebug bool
+}
+
+// RoundTrip performs a round-trip HTTP request and logs relevant information about it.
+func (lrt *LogRoundTripper) RoundTrip(request *http.Request) (*http.Response, error) {
+	defer func() {
+		if request.Body != nil {
+			request.Body.Close()
+		}
+	}()
+
+	// for future reference, this is how to access the Transport struct:
+	//tlsconfig := lrt.Rt.(*http.Transport).TLSClientConfig
+
+	var err error
+
+	if lrt.OsDebug {
+		log.Printf("[DEBUG] FIC Request URL: %s %s", request.Method, request.URL)
+		log.Printf("[DEBUG] FIC Request Headers:\n%s", FormatHeaders(request.Header, "\n"))
+
+		if request.Body != nil {
+			request.Body, err = lrt.logRequest(request.Body, request.Header.Get("Content-Type"))
+			if err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	response, err := lrt.Rt.RoundTrip(request)
+	if response == nil {
+		return nil, err
+	}
+
+	if lrt.OsDebug {
+		log.Printf("[DEBUG] FIC Response Code: %d", response.StatusCode)
+		log.Printf("[DEBUG] FIC Response Headers:\n%s", FormatHeaders(response.Header, "\n"))
+
+		response.Body, err = lrt.logResponse(response.Body, response.Header.Get("Content-Type"))
+	}
+
+	return response, err
+}
+
+// logRequest will log the HTTP Request details.
+// If the body is JSON, it will attempt to be pretty-formatted.
+func (lrt *LogRoundTripper) logRequest(original io.ReadCloser, contentType string) (io.ReadCloser, error) {
+	defer original.Close()
+
+	var bs bytes.Buffer
+	_, err := io.Copy(&bs, original)
+	if err != nil {
+		return nil, err
+	}
+
+	// Handle request contentType
+	if strings.HasPrefix(contentType, "application/json") {
+		debugInfo := lrt.formatJSON(bs.Bytes())
+		log.Printf("[DEBUG] FIC Request Body: %s", truncateBody(debugInfo))
+	}
+
+	return ioutil.NopCloser(strings.NewReader(bs.String())), nil
+}
+
+// logResponse will log the HTTP Response details.
+// If the body is JSON, it will attempt to be pretty-formatted.
+func (lrt *LogRoundTripper) logResponse(original io.ReadCloser, contentType string) (io.ReadCloser, error) {
+	if strings.HasPrefix(contentType, "application/json") {
+		var bs bytes.Buffer
+		defer original.Close()
+		_, err := io.Copy(&bs, original)
+		if err != nil {
+			return nil, err
+		}
+		debugInfo := lrt.formatJSON(bs.Bytes())
+		if debugInfo != "" {
+			log.Printf("[DEBUG] FIC Response Body: %s", truncateBody(debugInfo))
+		}
+		return ioutil.NopCloser(strings.NewReader(bs.String())), nil
+	}
+
+	log.Printf("[DEBUG] Not logging because FIC response body isn't JSON")
+	return original, nil
+}
+
+// formatJSON will try to pretty-format a JSON body.
+// It will also mask the values of rawJSONSensitiveKeys and the token ID of
+// Keystone requests, which contain sensitive information.
+func (lrt *LogRoundTripper) formatJSON(raw []byte) string {
+	var data map[string]interface{}
+
+	err := json.Unmarshal(raw, &data)
+	if err != nil {
+		log.Printf("[DEBUG] Unable to parse FIC JSON: %s", err)
+		return string(raw)
+	}
+
+	// Ignore the catalog
+	if v, ok := data["token"].(map[string]interface{}); ok {
+		if _, ok := v["catalog"]; ok {
+			return ""
+		}
+	}
+
+	// Mask the token ID of a token authentication request
+	if v, ok := data["auth"].(map[string]interface{}); ok {
+		if v, ok := v["identity"].(map[string]interface{}); ok {
+			if v, ok := v["token"].(map[string]interface{}); ok {
+				v["id"] = "***"
+			}
+		}
+	}
+
+	scrubRawJSON(data)
+
+	pretty, err := json.MarshalIndent(data, "", "  ")
+	if err != nil {
+		log.Printf("[DEBUG] Unable to re-marshal FIC JSON: %s", err)
+		return string(raw)
+	}
+
+	return string(pretty)
+}
+
+// logOperation wraps a CRUD function of the resource type typ so that the
+// start and the end of the operation are logged with the resource ID. The
+// fields are written as key=value pairs so that they can be filtered in
+// TF_LOG output. The ID logged at the end of a create is the new one.
+func logOperation(typ, operation string, f func(*schema.ResourceData, interface{}) error) func(*schema.ResourceData, interface{}) error {
+	if f == nil {
+		return nil
+	}
+
+	return func(d *schema.ResourceData, meta interface{}) error {
+		log.Printf("[DEBUG] FIC resource operation started: resource_type=%s operation=%s id=%q", typ, operation, d.Id())
+
+		start := time.Now()
+		err := f(d, meta)
+		if err != nil {
+			log.Printf("[DEBUG] FIC resource operation failed: resource_type=%s operation=%s id=%q duration=%s error=%q",
+				typ, operation, d.Id(), time.Since(start).Round(time.Millisecond), err)
+			return err
+		}
+
+		log.Printf("[DEBUG] FIC resource operation finished: resource_type=%s operation=%s id=%q duration=%s",
+			typ, operation, d.Id(), time.Since(start).Round(time.Millisecond))
+		return nil
+	}
+}
+
+// logOperations applies logOperation to the CRUD functions of r.
+func logOperations(typ string, r *schema.Resource) {
+	r.Create = logOperation(typ, "create", r.Create)
+	r.Read = logOperation(typ, "read", r.Read)
+	r.Update = logOperation(typ, "update", r.Update)
+	r.Delete = logOperation(typ, "delete", r.Delete)
+}
@@ -0,0 +1,218 @@
+package fic
+
+import (
+	"bytes"
+	"errors"
+	"io/ioutil"
+	"log"
+	"net/http"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+)
+
+type fakeRoundTripper struct {
+	response *http.Response
+}
+
+func (f *fakeRoundTripper) RoundTrip(request *http.Request) (*http.Response, error) {
+	f.response.Request = request
+	return f.response, nil
+}
+
+func TestLogRoundTripperRedaction(t *testing.T) {
+	var buf bytes.Buffer
+	log.SetOutput(&buf)
+	defer log.SetOutput(os.Stderr)
+
+	rt := &LogRoundTripper{
+		Rt: &fakeRoundTripper{
+			response: &http.Response{
+				StatusCode: http.StatusCreated,
+				Header: http.Header{
+					"Content-Type":    []string{"application/json"},
+					"X-Subject-Token": []string{"subject-secret"},
+				},
+				Body: ioutil.NopCloser(strings.NewReader(`{"token": {"expires_at": "2020-01-02T03:04:05Z"}}`)),
+			},
+		},
+		OsDebug: true,
+	}
+
+	body := `{"auth": {"identity": {"password": {"user": {"name": "user", "password": "password-secret"}}}}}`
+	request, err := http.NewRequest("POST", "https://keystone.example.com/v3/auth/tokens", strings.NewReader(body))
+	if err != nil {
+		t.Fatal(err)
+	}
+	request.Header.Set("Content-Type", "application/json")
+	request.Header.Set("X-Auth-Token", "token-secret")
+
+	response, err := rt.RoundTrip(request)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// The response body must still be readable after it has been logged.
+	if b, _ := ioutil.ReadAll(response.Body); !strings.Contains(string(b), "expires_at") {
+		t.Fatalf("unexpected response body: %s", b)
+	}
+
+	output := buf.String()
+	for _, secret := range []string{"token-secret", "subject-secret", "password-secret"} {
+		if strings.Contains(output, secret) {
+			t.Errorf("expected %q to be redacted, got:\n%s", secret, output)
+		}
+	}
+
+	for _, expected := range []string{
+		"FIC Request URL: POST https://keystone.example.com/v3/auth/tokens",
+		"X-Auth-Token: ***",
+		"FIC Response Code: 201",
+		"expires_at",
+	} {
+		if !strings.Contains(output, expected) {
+			t.Errorf("expected log to contain %q, got:\n%s", expected, output)
+		}
+	}
+}
+
+func TestLogRoundTripperConnectionRedaction(t *testing.T) {
+	var buf bytes.Buffer
+	log.SetOutput(&buf)
+	defer log.SetOutput(os.Stderr)
+
+	rt := &LogRoundTripper{
+		Rt: &fakeRoundTripper{
+			response: &http.Response{
+				StatusCode: http.StatusAccepted,
+				Header:     http.Header{"Content-Type": []string{"application/json"}},
+				Body: ioutil.NopCloser(strings.NewReader(`{"connection": {"id": "F030123456789", "destination": {
+					"primary": {"interconnect": "Equinix-TY2-2", "pairingKey": "pairing-secret"},
+					"qosType": "guarantee", "serviceKey": "service-secret"}}}`)),
+			},
+		},
+		OsDebug: true,
+	}
+
+	body := `{"connection": {"name": "terraform_connection_1", "destination": {
+		"eclTenantId": "ecl-tenant", "eclApiKey": "api-key-secret", "eclApiSecretKey": "api-secret-secret",
+		"primary": [{"asn": "65000", "sharedKey": "shared-secret"}]}}}`
+	request, err := http.NewRequest("POST", "https://eri.example.com/v1/router-to-ecl-connections", strings.NewReader(body))
+	if err != nil {
+		t.Fatal(err)
+	}
+	request.Header.Set("Content-Type", "application/json")
+
+	if _, err := rt.RoundTrip(request); err != nil {
+		t.Fatal(err)
+	}
+
+	output := buf.String()
+	for _, secret := range []string{"api-key-secret", "api-secret-secret", "shared-secret", "pairing-secret", "service-secret"} {
+		if strings.Contains(output, secret) {
+			t.Errorf("expected %q to be redacted, got:\n%s", secret, output)
+		}
+	}
+
+	for _, expected := range []string{"ecl-tenant", "Equinix-TY2-2", `"eclApiKey": "***"`, `"pairingKey": "***"`} {
+		if !strings.Contains(output, expected) {
+			t.Errorf("expected log to contain %q, got:\n%s", expected, output)
+		}
+	}
+}
+
+func TestTruncateBody(t *testing.T) {
+	short := "short"
+	if actual := truncateBody(short); actual != short {
+		t.Fatalf("expected %q, got %q", short, actual)
+	}
+
+	long := strings.Repeat("a", maxLoggedBodySize+10)
+	actual := truncateBody(long)
+	if !strings.HasSuffix(actual, "... (10 bytes truncated)") || len(actual) > maxLoggedBodySize+30 {
+		t.Fatalf("unexpected truncated body: %q", actual[maxLoggedBodySize:])
+	}
+}
+
+func TestDebugEnabled(t *testing.T) {
+	for _, env := range []string{"OS_DEBUG", "FIC_DEBUG"} {
+		original, ok := os.LookupEnv(env)
+		defer func(env, original string, ok bool) {
+			if ok {
+				os.Setenv(env, original)
+			} else {
+				os.Unsetenv(env)
+			}
+		}(env, original, ok)
+	}
+
+	cases := []struct {
+		osDebug  string
+		ficDebug string
+		expected bool
+	}{
+		{"", "", false},
+		{"1", "", true},
+		{"", "true", true},
+		{"", "1", true},
+		{"", "false", false},
+		{"", "yes", false},
+	}
+
+	for _, tc := range cases {
+		os.Setenv("OS_DEBUG", tc.osDebug)
+		os.Setenv("FIC_DEBUG", tc.ficDebug)
+
+		if actual := debugEnabled(); actual != tc.expected {
+			t.Errorf("OS_DEBUG=%q FIC_DEBUG=%q: expected %t, got %t", tc.osDebug, tc.ficDebug, tc.expected, actual)
+		}
+	}
+}
+
+func TestLogOperations(t *testing.T) {
+	var buf bytes.Buffer
+	log.SetOutput(&buf)
+	defer log.SetOutput(os.Stderr)
+
+	r := &schema.Resource{
+		Create: func(d *schema.ResourceData, meta interface{}) error {
+			d.SetId("F030123456789")
+			return nil
+		},
+		Delete: func(d *schema.ResourceData, meta interface{}) error {
+			return errors.New("conflict")
+		},
+		Schema: map[string]*schema.Schema{},
+	}
+	logOperations("fic_eri_port_to_port_connection_v1", r)
+
+	if r.Update != nil {
+		t.Fatal("expected a missing Update to stay nil")
+	}
+
+	d := r.TestResourceData()
+	if err := r.Create(d, nil); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	for _, expected := range []string{
+		`FIC resource operation started: resource_type=fic_eri_port_to_port_connection_v1 operation=create id=""`,
+		`FIC resource operation finished: resource_type=fic_eri_port_to_port_connection_v1 operation=create id="F030123456789" duration=`,
+	} {
+		if !strings.Contains(buf.String(), expected) {
+			t.Errorf("expected %q in log:\n%s", expected, buf.String())
+		}
+	}
+
+	buf.Reset()
+	if err := r.Delete(d, nil); err == nil {
+		t.Fatal("expected the error of Delete to be returned")
+	}
+
+	expected := `FIC resource operation failed: resource_type=fic_eri_port_to_port_connection_v1 operation=delete id="F030123456789"`
+	if !strings.Contains(buf.String(), expected) || !strings.Contains(buf.String(), `error="conflict"`) {
+		t.Errorf("expected %q with the error in log:\n%s", expected, buf.String())
+	}
+}
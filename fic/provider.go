@@ -1,8 +1,11 @@
 package fic
 
 import (
+	"time"
+
 	"github.com/hashicorp/terraform-plugin-sdk/helper/mutexkv"
 	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/validation"
 	"github.com/hashicorp/terraform-plugin-sdk/terraform"
 )
 
@@ -24,13 +27,13 @@ func Provider() terraform.ResourceProvider {
 				Type:        schema.TypeString,
 				Optional:    true,
 				Description: descriptions["region"],
-				DefaultFunc: schema.EnvDefaultFunc("OS_REGION_NAME", ""),
+				DefaultFunc: schema.MultiEnvDefaultFunc([]string{"FIC_REGION", "OS_REGION_NAME"}, ""),
 			},
 
 			"user_name": &schema.Schema{
 				Type:        schema.TypeString,
 				Optional:    true,
-				DefaultFunc: schema.EnvDefaultFunc("OS_USERNAME", ""),
+				DefaultFunc: schema.MultiEnvDefaultFunc([]string{"OS_USERNAME", "FIC_API_KEY"}, ""),
 				Description: descriptions["user_name"],
 			},
 
@@ -65,16 +68,18 @@ func Provider() terraform.ResourceProvider {
 				Type:        schema.TypeString,
 				Optional:    true,
 				Sensitive:   true,
-				DefaultFunc: schema.EnvDefaultFunc("OS_PASSWORD", ""),
+				DefaultFunc: schema.MultiEnvDefaultFunc([]string{"OS_PASSWORD", "FIC_API_SECRET"}, ""),
 				Description: descriptions["password"],
 			},
 
 			"token": &schema.Schema{
-				Type:     schema.TypeString,
-				Optional: true,
+				Type:      schema.TypeString,
+				Optional:  true,
+				Sensitive: true,
 				DefaultFunc: schema.MultiEnvDefaultFunc([]string{
 					"OS_TOKEN",
 					"OS_AUTH_TOKEN",
+					"FIC_TOKEN",
 				}, ""),
 				Description: descriptions["token"],
 			},
@@ -175,10 +180,118 @@ func Provider() terraform.ResourceProvider {
 				DefaultFunc: schema.EnvDefaultFunc("OS_FORCE_SSS_ENDPOINT", ""),
 				Description: descriptions["force_sss_endpoint"],
 			},
+
+			"redact_headers": &schema.Schema{
+				Type:        schema.TypeList,
+				Optional:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				Description: descriptions["redact_headers"],
+			},
+
+			"endpoint_overrides": &schema.Schema{
+				Type:         schema.TypeMap,
+				Optional:     true,
+				Elem:         &schema.Schema{Type: schema.TypeString},
+				ValidateFunc: ValidateEndpointOverrides(),
+				Description:  descriptions["endpoint_overrides"],
+			},
+
+			"http_timeout_seconds": &schema.Schema{
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Default:      60,
+				ValidateFunc: validation.IntAtLeast(0),
+				Description:  descriptions["http_timeout_seconds"],
+			},
+
+			"tls_handshake_timeout_seconds": &schema.Schema{
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Default:      10,
+				ValidateFunc: validation.IntAtLeast(0),
+				Description:  descriptions["tls_handshake_timeout_seconds"],
+			},
+
+			"max_idle_conns": &schema.Schema{
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Default:      100,
+				ValidateFunc: validation.IntAtLeast(0),
+				Description:  descriptions["max_idle_conns"],
+			},
+
+			"retry_base_delay": &schema.Schema{
+				Type:         schema.TypeInt,
+				Optional:     true,
+				DefaultFunc:  schema.EnvDefaultFunc("FIC_RETRY_BASE_DELAY", 1),
+				ValidateFunc: validation.IntAtLeast(0),
+				Description:  descriptions["retry_base_delay"],
+			},
+
+			"retry_max_delay": &schema.Schema{
+				Type:         schema.TypeInt,
+				Optional:     true,
+				DefaultFunc:  schema.EnvDefaultFunc("FIC_RETRY_MAX_DELAY", 30),
+				ValidateFunc: validation.IntAtLeast(0),
+				Description:  descriptions["retry_max_delay"],
+			},
+
+			"retry_max_attempts": &schema.Schema{
+				Type:         schema.TypeInt,
+				Optional:     true,
+				DefaultFunc:  schema.EnvDefaultFunc("FIC_RETRY_MAX_ATTEMPTS", 0),
+				ValidateFunc: validation.IntAtLeast(0),
+				Description:  descriptions["retry_max_attempts"],
+			},
+
+			"disable_retries": &schema.Schema{
+				Type:        schema.TypeBool,
+				Optional:    true,
+				DefaultFunc: schema.EnvDefaultFunc("FIC_DISABLE_RETRIES", false),
+				Description: descriptions["disable_retries"],
+			},
+
+			"expose_raw_json": &schema.Schema{
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				Description: descriptions["expose_raw_json"],
+			},
+
+			"max_concurrent_operations": &schema.Schema{
+				Type:         schema.TypeInt,
+				Optional:     true,
+				DefaultFunc:  schema.EnvDefaultFunc("FIC_MAX_CONCURRENT_OPERATIONS", 4),
+				ValidateFunc: validation.IntAtLeast(0),
+				Description:  descriptions["max_concurrent_operations"],
+			},
+
+			"wait_for_active": &schema.Schema{
+				Type:        schema.TypeBool,
+				Optional:    true,
+				DefaultFunc: schema.EnvDefaultFunc("FIC_WAIT_FOR_ACTIVE", true),
+				Description: descriptions["wait_for_active"],
+			},
+
+			"api_version": &schema.Schema{
+				Type:         schema.TypeString,
+				Optional:     true,
+				DefaultFunc:  schema.EnvDefaultFunc("FIC_API_VERSION", ""),
+				ValidateFunc: ValidateAPIVersion(),
+				Description:  descriptions["api_version"],
+			},
 		},
 
 		DataSourcesMap: map[string]*schema.Resource{
-			"fic_eri_switch_v1": dataSourceEriSwitchV1(),
+			"fic_eri_connections_v1":               dataSourceEriConnectionsV1(),
+			"fic_eri_port_v1":                      dataSourceEriPortV1(),
+			"fic_eri_port_vlans_v1":                dataSourceEriPortVLANsV1(),
+			"fic_eri_router_connection_v1":         dataSourceEriRouterConnectionV1(),
+			"fic_eri_router_to_port_connection_v1": dataSourceEriRouterToPortConnectionV1(),
+			"fic_eri_router_v1":                    dataSourceEriRouterV1(),
+			"fic_eri_switch_v1":                    dataSourceEriSwitchV1(),
+			"fic_eri_switches_v1":                  dataSourceEriSwitchesV1(),
+			"fic_request_preview_v1":               dataSourceRequestPreviewV1(),
 		},
 
 		ResourcesMap: map[string]*schema.Resource{
@@ -200,6 +313,13 @@ func Provider() terraform.ResourceProvider {
 		},
 	}
 
+	// The operation slot is taken outside of the logged operation, so that
+	// the logged duration does not include the wait for a free slot.
+	for name, r := range provider.ResourcesMap {
+		logOperations(name, r)
+		limitOperations(name, r)
+	}
+
 	provider.ConfigureFunc = func(d *schema.ResourceData) (interface{}, error) {
 		terraformVersion := provider.TerraformVersion
 		if terraformVersion == "" {
@@ -207,7 +327,13 @@ func Provider() terraform.ResourceProvider {
 			// We can therefore assume that if it's missing it's 0.10 or 0.11
 			terraformVersion = "0.11+compatible"
 		}
-		return configureProvider(d, terraformVersion)
+		config, err := configureProvider(d, terraformVersion)
+		if err != nil {
+			return nil, err
+		}
+
+		config.(*Config).stopContext = provider.StopContext()
+		return config, nil
 	}
 
 	return provider
@@ -260,11 +386,38 @@ func init() {
 		"key": "A client private key to authenticate with.",
 
 		"cloud": "An entry in a `clouds.yaml` file to use.",
+
+		"redact_headers": "Additional HTTP header names whose values are masked in debug logs.",
+
+		"endpoint_overrides": "A map of service names to base URLs replacing the endpoints of the service catalog.",
+
+		"http_timeout_seconds": "Timeout in seconds of a single HTTP request. 0 means no timeout.",
+
+		"tls_handshake_timeout_seconds": "Timeout in seconds of the TLS handshake. 0 means no timeout.",
+
+		"max_idle_conns": "Maximum number of idle keep-alive connections. 0 means no limit.",
+
+		"retry_base_delay": "Initial delay in seconds between retries of a conflicting or unavailable API request.",
+
+		"retry_max_delay": "Maximum delay in seconds between retries of an API request.",
+
+		"retry_max_attempts": "Maximum number of attempts of a retried API request. 0 means no limit other than the timeout.",
+
+		"disable_retries": "Return the first error of an API request instead of retrying conflicts, unavailable services and network errors.",
+
+		"expose_raw_json": "Store the API response of connection resources in their raw_json attribute.",
+
+		"max_concurrent_operations": "Maximum number of resources created, updated or deleted at the same time. Each slot is held until FIC has completed the operation. 0 means no limit.",
+
+		"wait_for_active": "Wait for created resources to become active before returning.",
+
+		"api_version": "The microversion of the ERI API to request, e.g. `1.0`. Defaults to the version chosen by FIC.",
 	}
 }
 
 func configureProvider(d *schema.ResourceData, terraformVersion string) (interface{}, error) {
 	config := Config{
+		APIVersion:        d.Get("api_version").(string),
 		CACertFile:        d.Get("cacert_file").(string),
 		ClientCertFile:    d.Get("cert").(string),
 		ClientKeyFile:     d.Get("key").(string),
@@ -286,6 +439,32 @@ func configureProvider(d *schema.ResourceData, terraformVersion string) (interfa
 		Username:          d.Get("user_name").(string),
 		UserID:            d.Get("user_id").(string),
 		terraformVersion:  terraformVersion,
+
+		HTTPTimeout:         time.Duration(d.Get("http_timeout_seconds").(int)) * time.Second,
+		TLSHandshakeTimeout: time.Duration(d.Get("tls_handshake_timeout_seconds").(int)) * time.Second,
+		MaxIdleConns:        d.Get("max_idle_conns").(int),
+		ExposeRawJSON:       d.Get("expose_raw_json").(bool),
+		SkipWaitForActive:   !d.Get("wait_for_active").(bool),
+	}
+
+	config.Backoff = Backoff{
+		BaseDelay:   time.Duration(d.Get("retry_base_delay").(int)) * time.Second,
+		MaxDelay:    time.Duration(d.Get("retry_max_delay").(int)) * time.Second,
+		MaxAttempts: d.Get("retry_max_attempts").(int),
+		Disabled:    d.Get("disable_retries").(bool),
+	}
+
+	config.operations = newOperationLimiter(d.Get("max_concurrent_operations").(int))
+
+	for _, h := range d.Get("redact_headers").([]interface{}) {
+		config.RedactHeaders = append(config.RedactHeaders, h.(string))
+	}
+
+	if v, ok := d.GetOk("endpoint_overrides"); ok {
+		config.EndpointOverrides = make(map[string]string)
+		for service, url := range v.(map[string]interface{}) {
+			config.EndpointOverrides[service] = url.(string)
+		}
 	}
 
 	v, ok := d.GetOkExists("insecure")
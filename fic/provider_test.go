@@ -3,13 +3,18 @@ package fic
 import (
 	"fmt"
 	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
 	"os"
+	"regexp"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/hashicorp/terraform-plugin-sdk/helper/pathorcontents"
 	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/terraform"
+	"github.com/nttcom/go-fic"
 	"github.com/terraform-providers/terraform-provider-google/google"
 )
 
@@ -134,6 +139,21 @@ func testAccPreCheckDeprecated(t *testing.T) {
 	}
 }
 
+// testMockConfig starts a test server answering with handler and returns a
+// provider configuration whose ERI client sends its requests there, along
+// with a function that stops the server.
+func testMockConfig(t *testing.T, handler http.Handler) (*Config, func()) {
+	t.Helper()
+
+	server := httptest.NewServer(handler)
+	config := &Config{
+		OsClient:          &fic.ProviderClient{},
+		EndpointOverrides: map[string]string{"eri": server.URL},
+	}
+
+	return config, server.Close
+}
+
 func TestProvider(t *testing.T) {
 	if err := Provider().(*schema.Provider).InternalValidate(); err != nil {
 		t.Fatalf("err: %s", err)
@@ -144,6 +164,130 @@ func TestProvider_impl(t *testing.T) {
 	var _ terraform.ResourceProvider = Provider()
 }
 
+func TestProvider_resourceTimeouts(t *testing.T) {
+	raw := map[string]interface{}{
+		"timeouts": map[string]interface{}{
+			"create": "25m",
+			"delete": "35m",
+		},
+	}
+	expected := map[string]time.Duration{
+		schema.TimeoutCreate: 25 * time.Minute,
+		schema.TimeoutDelete: 35 * time.Minute,
+	}
+
+	for name, r := range Provider().(*schema.Provider).ResourcesMap {
+		if r.Update != nil {
+			raw["timeouts"].(map[string]interface{})["update"] = "30m"
+			expected[schema.TimeoutUpdate] = 30 * time.Minute
+		} else {
+			delete(raw["timeouts"].(map[string]interface{}), "update")
+			delete(expected, schema.TimeoutUpdate)
+		}
+
+		var rt schema.ResourceTimeout
+		if err := rt.ConfigDecode(r, terraform.NewResourceConfigRaw(raw)); err != nil {
+			t.Fatalf("%s: unexpected error decoding timeouts: %s", name, err)
+		}
+
+		actual := map[string]*time.Duration{
+			schema.TimeoutCreate: rt.Create,
+			schema.TimeoutUpdate: rt.Update,
+			schema.TimeoutDelete: rt.Delete,
+		}
+
+		for key, v := range expected {
+			if actual[key] == nil || *actual[key] != v {
+				t.Errorf("%s: expected %s timeout %s, got %v", name, key, v, actual[key])
+			}
+		}
+	}
+}
+
+// walkSchema calls f with the dotted path of every attribute in m, including
+// the attributes of nested blocks.
+func walkSchema(prefix string, m map[string]*schema.Schema, f func(string, *schema.Schema)) {
+	for k, s := range m {
+		f(prefix+k, s)
+		if r, ok := s.Elem.(*schema.Resource); ok {
+			walkSchema(prefix+k+".", r.Schema, f)
+		}
+	}
+}
+
+func TestProvider_sensitiveAttributes(t *testing.T) {
+	expected := map[string][]string{
+		"fic_eri_port_to_azure_microsoft_connection_v1":   {"destination_service_key", "destination_shared_key"},
+		"fic_eri_port_to_azure_private_connection_v1":     {"destination_service_key", "destination_shared_key"},
+		"fic_eri_router_paired_to_gcp_connection_v1":      {"destination.primary.pairing_key", "destination.secondary.pairing_key"},
+		"fic_eri_router_to_azure_microsoft_connection_v1": {"destination_service_key"},
+		"fic_eri_router_to_azure_private_connection_v1":   {"destination_service_key"},
+		"fic_eri_router_to_ecl_connection_v1":             {"destination_ecl_api_key", "destination_ecl_api_secret_key"},
+	}
+	secret := regexp.MustCompile(`(^|_)(key|secret|password|token)$`)
+
+	p := Provider().(*schema.Provider)
+	for name, r := range p.ResourcesMap {
+		found := make(map[string]*schema.Schema)
+		walkSchema("", r.Schema, func(path string, s *schema.Schema) {
+			found[path] = s
+			if secret.MatchString(path) && !s.Sensitive {
+				t.Errorf("%s: expected %s to be sensitive", name, path)
+			}
+		})
+
+		for _, path := range expected[name] {
+			if s, ok := found[path]; !ok || !s.Sensitive {
+				t.Errorf("%s: expected a sensitive %s attribute", name, path)
+			}
+		}
+	}
+
+	for _, k := range []string{"password", "token"} {
+		if !p.Schema[k].Sensitive {
+			t.Errorf("expected the provider %s to be sensitive", k)
+		}
+	}
+}
+
+func TestProvider_regionDefault(t *testing.T) {
+	cases := []struct {
+		ficEnv   string
+		osEnv    string
+		expected string
+	}{
+		{"fic", "os", "fic"},
+		{"", "os", "os"},
+		{"", "", ""},
+	}
+
+	for _, env := range []string{"FIC_REGION", "OS_REGION_NAME"} {
+		original, ok := os.LookupEnv(env)
+		defer func(env, original string, ok bool) {
+			if ok {
+				os.Setenv(env, original)
+			} else {
+				os.Unsetenv(env)
+			}
+		}(env, original, ok)
+	}
+
+	region := Provider().(*schema.Provider).Schema["region"]
+	for _, tc := range cases {
+		os.Setenv("FIC_REGION", tc.ficEnv)
+		os.Setenv("OS_REGION_NAME", tc.osEnv)
+
+		actual, err := region.DefaultFunc()
+		if err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+
+		if actual != tc.expected {
+			t.Errorf("FIC_REGION=%q OS_REGION_NAME=%q: expected %q, got %q", tc.ficEnv, tc.osEnv, tc.expected, actual)
+		}
+	}
+}
+
 // Steps for configuring Flexible InterConnect with SSL validation are here:
 // https://github.com/hashicorp/terraform/pull/6279#issuecomment-219020144
 func TestAccProvider_caCertFile(t *testing.T) {
@@ -26,6 +26,7 @@ func resourceEriFirewallComponentV1() *schema.Resource {
 
 		Timeouts: &schema.ResourceTimeout{
 			Create: schema.DefaultTimeout(10 * time.Minute),
+			Update: schema.DefaultTimeout(10 * time.Minute),
 			Delete: schema.DefaultTimeout(10 * time.Minute),
 		},
 
@@ -47,7 +48,11 @@ func resourceEriFirewallComponentV1() *schema.Resource {
 				Type:     schema.TypeList,
 				Required: true,
 				ForceNew: true,
-				Elem:     &schema.Schema{Type: schema.TypeString},
+				Elem: &schema.Schema{
+					Type:             schema.TypeString,
+					ValidateFunc:     ValidateCIDR(),
+					DiffSuppressFunc: suppressEquivalentCIDRDiff,
+				},
 			},
 
 			"rules": &schema.Schema{
@@ -174,7 +179,11 @@ func resourceEriFirewallComponentV1() *schema.Resource {
 										Type:     schema.TypeList,
 										Required: true,
 										MaxItems: 10,
-										Elem:     &schema.Schema{Type: schema.TypeString},
+										Elem: &schema.Schema{
+											Type:             schema.TypeString,
+											ValidateFunc:     ValidateCIDR(),
+											DiffSuppressFunc: suppressEquivalentCIDRDiff,
+										},
 									},
 								},
 							},
@@ -192,6 +201,11 @@ func resourceEriFirewallComponentV1() *schema.Resource {
 				Type:     schema.TypeBool,
 				Computed: true,
 			},
+
+			"uri": &schema.Schema{
+				Type:     schema.TypeString,
+				Computed: true,
+			},
 		},
 	}
 }
@@ -213,7 +227,7 @@ func resourceEriFirewallComponentV1Activate(d *schema.ResourceData, meta interfa
 	log.Printf("[DEBUG] Activate Options: %#v", activateOpts)
 	r, err := firewalls.Activate(client, routerID, firewallID, activateOpts).Extract()
 	if err != nil {
-		return fmt.Errorf("Error activating FIC ERI firewall component: %s", err)
+		return formatFICError(err, "Error activating FIC ERI firewall component")
 	}
 
 	id := fmt.Sprintf("%s/%s", routerID, firewallID)
@@ -221,19 +235,14 @@ func resourceEriFirewallComponentV1Activate(d *schema.ResourceData, meta interfa
 
 	log.Printf("[INFO] firewall component ID: %s", r.ID)
 
-	log.Printf(
-		"[DEBUG] Waiting for firewall component (%s) to become available", r.ID)
-
-	stateConf := &resource.StateChangeConf{
-		Pending:    []string{"Processing"},
-		Target:     []string{"Completed"},
-		Refresh:    FirewallComponentV1StateRefreshFunc(client, id),
-		Timeout:    d.Timeout(schema.TimeoutCreate),
-		Delay:      10 * time.Second,
-		MinTimeout: 3 * time.Second,
-	}
-
-	_, err = stateConf.WaitForState()
+	_, err = waitForStatus(config.StopContext(), statusWait{
+		ResourceType: "firewall component",
+		ID:           id,
+		Refresh:      FirewallComponentV1StateRefreshFunc(client, id),
+		Pending:      []string{"Processing"},
+		Target:       []string{"Completed"},
+		Timeout:      d.Timeout(schema.TimeoutCreate),
+	})
 	if err != nil {
 		return fmt.Errorf(
 			"Error waiting for firewall component (%s) to become ready: %s", r.ID, err)
@@ -250,7 +259,9 @@ func resourceEriFirewallComponentV1Activate(d *schema.ResourceData, meta interfa
 	log.Printf("[DEBUG] RoutingGroupSettings  are set as: %#v", routingGroupSettings)
 
 	if len(rules) > 0 || len(customApplications) > 0 || len(applicationSets) > 0 || len(routingGroupSettings) > 0 {
-		updateFirewall(d, meta)
+		if err := updateFirewall(d, meta, d.Timeout(schema.TimeoutCreate)); err != nil {
+			return err
+		}
 	}
 
 	return resourceEriFirewallComponentV1Read(d, meta)
@@ -273,6 +284,7 @@ func resourceEriFirewallComponentV1Read(d *schema.ResourceData, meta interface{}
 
 	log.Printf("[DEBUG] Retrieved firewall component %s: %+v", d.Id(), r)
 
+	d.Set("uri", client.ServiceURL("routers", routerID, "firewalls", firewallID))
 	d.Set("router_id", d.Get("router_id").(string))
 	d.Set("firewall_id", d.Get("firewall_id").(string))
 	d.Set("user_ip_addresses", r.UserIPAddresses)
@@ -292,12 +304,14 @@ func resourceEriFirewallComponentV1Update(d *schema.ResourceData, meta interface
 		d.HasChange("application_sets") || d.HasChange("routing_group_settings") {
 
 		log.Printf("[DEBUG] Firewall is going to update...")
-		updateFirewall(d, meta)
+		if err := updateFirewall(d, meta, d.Timeout(schema.TimeoutUpdate)); err != nil {
+			return err
+		}
 	}
 	return resourceEriFirewallComponentV1Read(d, meta)
 }
 
-func updateFirewall(d *schema.ResourceData, meta interface{}) error {
+func updateFirewall(d *schema.ResourceData, meta interface{}, timeout time.Duration) error {
 	config := meta.(*Config)
 	client, err := config.eriV1Client(GetRegion(d, config))
 	if err != nil {
@@ -319,23 +333,17 @@ func updateFirewall(d *schema.ResourceData, meta interface{}) error {
 		}
 		_, err := firewalls.Update(client, routerID, firewallID, updateOpts).Extract()
 		if err != nil {
-			return fmt.Errorf("Error updating FIC ERI nat component: %s", err)
-		}
-
-		log.Printf(
-			"[DEBUG] Waiting for firewall component (%s) to become complete", d.Id())
-
-		activateStateConf := &resource.StateChangeConf{
-			Pending:    []string{"Processing"},
-			Target:     []string{"Completed"},
-			Refresh:    FirewallComponentV1StateRefreshFunc(client, d.Id()),
-			Timeout:    d.Timeout(schema.TimeoutCreate),
-			Delay:      10 * time.Second,
-			MinTimeout: 3 * time.Second,
+			return formatFICError(err, "Error updating FIC ERI firewall component")
 		}
 
-		log.Printf("[DEBUG] Waiting for firewall component (%s) to become complete", d.Id())
-		_, err = activateStateConf.WaitForState()
+		_, err = waitForStatus(config.StopContext(), statusWait{
+			ResourceType: "firewall component",
+			ID:           d.Id(),
+			Refresh:      FirewallComponentV1StateRefreshFunc(client, d.Id()),
+			Pending:      []string{"Processing"},
+			Target:       []string{"Completed"},
+			Timeout:      timeout,
+		})
 		if err != nil {
 			return fmt.Errorf("Error waiting for firewall component (%s) to become complete: %s", d.Id(), err)
 		}
@@ -355,18 +363,14 @@ func resourceEriFirewallComponentV1Deactivate(d *schema.ResourceData, meta inter
 	natID := strings.Split(id, "/")[1]
 	_, err = firewalls.Deactivate(client, routerID, natID).Extract()
 
-	log.Printf("[DEBUG] Waiting for firewall component (%s) to delete", d.Id())
-
-	stateConf := &resource.StateChangeConf{
-		Pending:    []string{"Processing"},
-		Target:     []string{"Completed"},
-		Refresh:    FirewallComponentV1StateRefreshFunc(client, d.Id()),
-		Timeout:    d.Timeout(schema.TimeoutDelete),
-		Delay:      10 * time.Second,
-		MinTimeout: 3 * time.Second,
-	}
-
-	_, err = stateConf.WaitForState()
+	_, err = waitForStatus(config.StopContext(), statusWait{
+		ResourceType: "firewall component",
+		ID:           d.Id(),
+		Refresh:      FirewallComponentV1StateRefreshFunc(client, d.Id()),
+		Pending:      []string{"Processing"},
+		Target:       []string{"Completed"},
+		Timeout:      d.Timeout(schema.TimeoutDelete),
+	})
 	if err != nil {
 		return fmt.Errorf(
 			"Error waiting for firewall component (%s) to delete: %s",
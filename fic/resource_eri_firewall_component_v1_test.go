@@ -1,12 +1,19 @@
 package fic
 
 import (
+	"context"
+	"errors"
 	"fmt"
+	"net/http"
+	"net/http/httptest"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/hashicorp/terraform-plugin-sdk/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/terraform"
+	"github.com/nttcom/go-fic"
 
 	"github.com/nttcom/go-fic/fic/eri/v1/routers/components/firewalls"
 )
@@ -281,3 +288,78 @@ resource "fic_eri_firewall_component_v1" "firewall_1" {
 `,
 	testAccConfigEriFirewallComponentV1Router,
 )
+
+func TestFirewallComponentV1StateRefreshFunc(t *testing.T) {
+	gets := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gets++
+		status := "Processing"
+		if gets > 2 {
+			status = "Completed"
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		fmt.Fprintf(w, `{"firewall": {"id": "F040123456789", "isActivated": true, "operationStatus": %q}}`, status)
+	}))
+	defer server.Close()
+
+	client := &fic.ServiceClient{
+		ProviderClient: &fic.ProviderClient{},
+		Endpoint:       server.URL + "/v1/",
+	}
+
+	stateConf := &resource.StateChangeConf{
+		Pending:    []string{"Processing"},
+		Target:     []string{"Completed"},
+		Refresh:    FirewallComponentV1StateRefreshFunc(client, "F020123456789/F040123456789"),
+		Timeout:    time.Minute,
+		MinTimeout: 10 * time.Millisecond,
+	}
+
+	v, err := waitForState(context.Background(), stateConf)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	if gets != 3 {
+		t.Fatalf("expected the poll to stop once the firewall is completed, got %d requests", gets)
+	}
+
+	if f := v.(*firewalls.Firewall); !f.IsActivated {
+		t.Fatalf("expected an activated firewall, got %+v", f)
+	}
+}
+
+func TestResourceEriFirewallComponentV1UpdateError(t *testing.T) {
+	config, closeServer := testMockConfig(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		if r.Method == http.MethodPut {
+			w.WriteHeader(http.StatusBadRequest)
+			fmt.Fprint(w, `{"errors": [{"field": "customApplications", "code": "ERI-01-0008", "message": "name is too long"}]}`)
+			return
+		}
+		fmt.Fprint(w, `{"firewall": {"id": "F040123456789", "isActivated": true, "operationStatus": "Completed"}}`)
+	}))
+	defer closeServer()
+
+	d := schema.TestResourceDataRaw(t, resourceEriFirewallComponentV1().Schema, map[string]interface{}{
+		"router_id":         "F020123456789",
+		"firewall_id":       "F040123456789",
+		"user_ip_addresses": []interface{}{"192.168.0.0/30"},
+		"custom_applications": []interface{}{
+			map[string]interface{}{"name": "google-drive-web", "protocol": "tcp", "destination_port": "443"},
+		},
+	})
+	d.SetId("F020123456789/F040123456789")
+
+	err := resourceEriFirewallComponentV1Update(d, config)
+
+	var e fic.ErrDefault400
+	if !errors.As(err, &e) {
+		t.Fatalf("expected the failed rule update to be returned, got %v", err)
+	}
+
+	if !strings.Contains(err.Error(), "name is too long") {
+		t.Fatalf("expected the API error in %q", err)
+	}
+}
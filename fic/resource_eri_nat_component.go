@@ -26,6 +26,7 @@ func resourceEriNATComponentV1() *schema.Resource {
 
 		Timeouts: &schema.ResourceTimeout{
 			Create: schema.DefaultTimeout(10 * time.Minute),
+			Update: schema.DefaultTimeout(10 * time.Minute),
 			Delete: schema.DefaultTimeout(10 * time.Minute),
 		},
 
@@ -47,7 +48,11 @@ func resourceEriNATComponentV1() *schema.Resource {
 				Type:     schema.TypeList,
 				Required: true,
 				ForceNew: true,
-				Elem:     &schema.Schema{Type: schema.TypeString},
+				Elem: &schema.Schema{
+					Type:             schema.TypeString,
+					ValidateFunc:     ValidateCIDR(),
+					DiffSuppressFunc: suppressEquivalentCIDRDiff,
+				},
 			},
 
 			"global_ip_address_sets": &schema.Schema{
@@ -174,6 +179,11 @@ func resourceEriNATComponentV1() *schema.Resource {
 				Type:     schema.TypeBool,
 				Computed: true,
 			},
+
+			"uri": &schema.Schema{
+				Type:     schema.TypeString,
+				Computed: true,
+			},
 		},
 	}
 }
@@ -196,7 +206,7 @@ func resourceEriNATComponentV1Activate(d *schema.ResourceData, meta interface{})
 	log.Printf("[DEBUG] Activate Options: %#v", activateOpts)
 	r, err := nats.Activate(client, routerID, natID, activateOpts).Extract()
 	if err != nil {
-		return fmt.Errorf("Error activating FIC ERI nat component: %s", err)
+		return formatFICError(err, "Error activating FIC ERI nat component")
 	}
 
 	id := fmt.Sprintf("%s/%s", routerID, natID)
@@ -204,19 +214,14 @@ func resourceEriNATComponentV1Activate(d *schema.ResourceData, meta interface{})
 
 	log.Printf("[INFO] NAT Component ID: %s", r.ID)
 
-	log.Printf(
-		"[DEBUG] Waiting for nat component (%s) to become available", r.ID)
-
-	stateConf := &resource.StateChangeConf{
-		Pending:    []string{"Processing"},
-		Target:     []string{"Completed"},
-		Refresh:    NATComponentV1StateRefreshFunc(client, id),
-		Timeout:    d.Timeout(schema.TimeoutCreate),
-		Delay:      10 * time.Second,
-		MinTimeout: 3 * time.Second,
-	}
-
-	_, err = stateConf.WaitForState()
+	_, err = waitForStatus(config.StopContext(), statusWait{
+		ResourceType: "NAT component",
+		ID:           id,
+		Refresh:      NATComponentV1StateRefreshFunc(client, id),
+		Pending:      []string{"Processing"},
+		Target:       []string{"Completed"},
+		Timeout:      d.Timeout(schema.TimeoutCreate),
+	})
 	if err != nil {
 		return fmt.Errorf(
 			"Error waiting for nat component (%s) to become ready: %s", r.ID, err)
@@ -229,7 +234,7 @@ func resourceEriNATComponentV1Activate(d *schema.ResourceData, meta interface{})
 	log.Printf("[DEBUG] Destination NAT Rule is set as: %#v", destinationNATRules)
 
 	if len(sourceNAPTRules) > 0 || len(destinationNATRules) > 0 {
-		updateSourceNAPTORDestinationNAT(d, meta)
+		updateSourceNAPTORDestinationNAT(d, meta, d.Timeout(schema.TimeoutCreate))
 	}
 
 	return resourceEriNATComponentV1Read(d, meta)
@@ -252,6 +257,7 @@ func resourceEriNATComponentV1Read(d *schema.ResourceData, meta interface{}) err
 
 	log.Printf("[DEBUG] Retrieved nat component %s: %+v", d.Id(), r)
 
+	d.Set("uri", client.ServiceURL("routers", routerID, "nats", natID))
 	d.Set("router_id", d.Get("router_id").(string))
 	d.Set("user_ip_address", r.UserIPAddresses)
 	d.Set("source_napt_rules", getSourceNAPTRuleForState(r))
@@ -267,7 +273,7 @@ func resourceEriNATComponentV1Update(d *schema.ResourceData, meta interface{}) e
 	log.Printf("[DEBUG] d.HasChange('destination_nat_rules'): %#v", d.HasChange("source_napt_rules"))
 	if d.HasChange("source_napt_rules") || d.HasChange("destination_nat_rules") {
 		log.Printf("[DEBUG] Either Source NAPT or Destination NAT is going to update...")
-		updateSourceNAPTORDestinationNAT(d, meta)
+		updateSourceNAPTORDestinationNAT(d, meta, d.Timeout(schema.TimeoutUpdate))
 	}
 	return resourceEriNATComponentV1Read(d, meta)
 }
@@ -284,18 +290,14 @@ func resourceEriNATComponentV1Deactivate(d *schema.ResourceData, meta interface{
 	natID := strings.Split(id, "/")[1]
 	_, err = nats.Deactivate(client, routerID, natID).Extract()
 
-	log.Printf("[DEBUG] Waiting for nat component (%s) to delete", d.Id())
-
-	stateConf := &resource.StateChangeConf{
-		Pending:    []string{"Processing"},
-		Target:     []string{"Completed"},
-		Refresh:    NATComponentV1StateRefreshFunc(client, d.Id()),
-		Timeout:    d.Timeout(schema.TimeoutDelete),
-		Delay:      10 * time.Second,
-		MinTimeout: 3 * time.Second,
-	}
-
-	_, err = stateConf.WaitForState()
+	_, err = waitForStatus(config.StopContext(), statusWait{
+		ResourceType: "NAT component",
+		ID:           d.Id(),
+		Refresh:      NATComponentV1StateRefreshFunc(client, d.Id()),
+		Pending:      []string{"Processing"},
+		Target:       []string{"Completed"},
+		Timeout:      d.Timeout(schema.TimeoutDelete),
+	})
 	if err != nil {
 		return fmt.Errorf(
 			"Error waiting for nat component (%s) to delete: %s",
@@ -306,7 +308,7 @@ func resourceEriNATComponentV1Deactivate(d *schema.ResourceData, meta interface{
 	return nil
 }
 
-func updateSourceNAPTORDestinationNAT(d *schema.ResourceData, meta interface{}) error {
+func updateSourceNAPTORDestinationNAT(d *schema.ResourceData, meta interface{}, timeout time.Duration) error {
 	config := meta.(*Config)
 	client, err := config.eriV1Client(GetRegion(d, config))
 	if err != nil {
@@ -325,23 +327,17 @@ func updateSourceNAPTORDestinationNAT(d *schema.ResourceData, meta interface{})
 		}
 		_, err := nats.Update(client, routerID, natID, updateOpts).Extract()
 		if err != nil {
-			return fmt.Errorf("Error updating FIC ERI nat component: %s", err)
-		}
-
-		log.Printf(
-			"[DEBUG] Waiting for nat component (%s) to become complete", d.Id())
-
-		activateStateConf := &resource.StateChangeConf{
-			Pending:    []string{"Processing"},
-			Target:     []string{"Completed"},
-			Refresh:    NATComponentV1StateRefreshFunc(client, d.Id()),
-			Timeout:    d.Timeout(schema.TimeoutCreate),
-			Delay:      10 * time.Second,
-			MinTimeout: 3 * time.Second,
+			return formatFICError(err, "Error updating FIC ERI nat component")
 		}
 
-		log.Printf("[DEBUG] Waiting for nat component (%s) to become complete", d.Id())
-		_, err = activateStateConf.WaitForState()
+		_, err = waitForStatus(config.StopContext(), statusWait{
+			ResourceType: "NAT component",
+			ID:           d.Id(),
+			Refresh:      NATComponentV1StateRefreshFunc(client, d.Id()),
+			Pending:      []string{"Processing"},
+			Target:       []string{"Completed"},
+			Timeout:      timeout,
+		})
 		if err != nil {
 			return fmt.Errorf("Error waiting for nat component (%s) to become complete: %s", d.Id(), err)
 		}
@@ -43,9 +43,10 @@ func resourceEriNATGlobalIPAddressSetV1() *schema.Resource {
 			},
 
 			"name": &schema.Schema{
-				Type:     schema.TypeString,
-				Required: true,
-				ForceNew: true,
+				Type:         schema.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				ValidateFunc: ValidateFICName(),
 			},
 
 			"type": &schema.Schema{
@@ -68,6 +69,16 @@ func resourceEriNATGlobalIPAddressSetV1() *schema.Resource {
 				Computed: true,
 				Elem:     &schema.Schema{Type: schema.TypeString},
 			},
+
+			"operation_status": &schema.Schema{
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+
+			"uri": &schema.Schema{
+				Type:     schema.TypeString,
+				Computed: true,
+			},
 		},
 	}
 }
@@ -91,7 +102,7 @@ func resourceEriNATGlobalIPAddressSetV1Create(d *schema.ResourceData, meta inter
 	log.Printf("[DEBUG] Create Options: %#v", createOpts)
 	r, err := nat_global_ip_address_sets.Create(client, routerID, natID, createOpts).Extract()
 	if err != nil {
-		return fmt.Errorf("Error activating FIC ERI global ip address set: %s", err)
+		return formatFICError(err, "Error activating FIC ERI global ip address set")
 	}
 
 	globalIPAddressSetID := r.ID
@@ -100,19 +111,14 @@ func resourceEriNATGlobalIPAddressSetV1Create(d *schema.ResourceData, meta inter
 
 	log.Printf("[INFO] Global IP Address Set ID: %s", r.ID)
 
-	log.Printf(
-		"[DEBUG] Waiting for global ip address set (%s) to become available", r.ID)
-
-	stateConf := &resource.StateChangeConf{
-		Pending:    []string{"Processing"},
-		Target:     []string{"Completed"},
-		Refresh:    NATGlobalIPAddressSetV1StateRefreshFunc(client, id),
-		Timeout:    d.Timeout(schema.TimeoutCreate),
-		Delay:      10 * time.Second,
-		MinTimeout: 3 * time.Second,
-	}
-
-	_, err = stateConf.WaitForState()
+	_, err = config.waitForActive(statusWait{
+		ResourceType: "global IP address set",
+		ID:           id,
+		Refresh:      NATGlobalIPAddressSetV1StateRefreshFunc(client, id),
+		Pending:      []string{"Processing"},
+		Target:       []string{"Completed"},
+		Timeout:      d.Timeout(schema.TimeoutCreate),
+	})
 	if err != nil {
 		return fmt.Errorf(
 			"Error waiting for global ip address set (%s) to become ready: %s", r.ID, err)
@@ -139,6 +145,7 @@ func resourceEriNATGlobalIPAddressSetV1Read(d *schema.ResourceData, meta interfa
 
 	log.Printf("[DEBUG] Retrieved global ip address set %s: %+v", d.Id(), r)
 
+	d.Set("uri", client.ServiceURL("routers", routerID, "nats", natID, "global-ip-address-sets", globalIPAddressSetID))
 	d.Set("router_id", d.Get("router_id").(string))
 	d.Set("nat_id", d.Get("nat_id").(string))
 
@@ -166,18 +173,14 @@ func resourceEriNATGlobalIPAddressSetV1Delete(d *schema.ResourceData, meta inter
 	_, err = nat_global_ip_address_sets.Delete(
 		client, routerID, natID, globalIPAddressSetID).Extract()
 
-	log.Printf("[DEBUG] Waiting for global ip address set (%s) to delete", d.Id())
-
-	stateConf := &resource.StateChangeConf{
-		Pending:    []string{"Processing"},
-		Target:     []string{"Deleted"},
-		Refresh:    NATGlobalIPAddressSetV1StateRefreshFunc(client, d.Id()),
-		Timeout:    d.Timeout(schema.TimeoutDelete),
-		Delay:      10 * time.Second,
-		MinTimeout: 3 * time.Second,
-	}
-
-	_, err = stateConf.WaitForState()
+	_, err = waitForStatus(config.StopContext(), statusWait{
+		ResourceType: "global IP address set",
+		ID:           d.Id(),
+		Refresh:      NATGlobalIPAddressSetV1StateRefreshFunc(client, d.Id()),
+		Pending:      []string{"Processing"},
+		Target:       []string{"Deleted"},
+		Timeout:      d.Timeout(schema.TimeoutDelete),
+	})
 	if err != nil {
 		return fmt.Errorf(
 			"Error waiting for global ip address set (%s) to delete: %s",
@@ -22,16 +22,20 @@ func resourceEriPortToAzureMicrosoftConnectionV1() *schema.Resource {
 			State: schema.ImportStatePassthrough,
 		},
 
+		CustomizeDiff: resourceEriPortToAzureMicrosoftConnectionV1CustomizeDiff,
+
 		Timeouts: &schema.ResourceTimeout{
 			Create: schema.DefaultTimeout(10 * time.Minute),
+			Update: schema.DefaultTimeout(10 * time.Minute),
 			Delete: schema.DefaultTimeout(10 * time.Minute),
 		},
 
 		Schema: map[string]*schema.Schema{
 			"name": &schema.Schema{
-				Type:     schema.TypeString,
-				Required: true,
-				ForceNew: true,
+				Type:         schema.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				ValidateFunc: ValidateFICName(),
 			},
 
 			"source_primary_port_id": &schema.Schema{
@@ -59,9 +63,10 @@ func resourceEriPortToAzureMicrosoftConnectionV1() *schema.Resource {
 			},
 
 			"source_asn": &schema.Schema{
-				Type:     schema.TypeString,
-				Required: true,
-				ForceNew: true,
+				Type:         schema.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				ValidateFunc: ValidateASN(),
 			},
 
 			"destination_interconnect": &schema.Schema{
@@ -79,21 +84,27 @@ func resourceEriPortToAzureMicrosoftConnectionV1() *schema.Resource {
 			},
 
 			"destination_service_key": &schema.Schema{
-				Type:     schema.TypeString,
-				Required: true,
-				ForceNew: true,
+				Type:      schema.TypeString,
+				Required:  true,
+				ForceNew:  true,
+				Sensitive: true,
 			},
 
 			"destination_shared_key": &schema.Schema{
-				Type:     schema.TypeString,
-				Optional: true,
-				ForceNew: true,
+				Type:      schema.TypeString,
+				Optional:  true,
+				ForceNew:  true,
+				Sensitive: true,
 			},
 
 			"destination_advertised_public_prefixes": &schema.Schema{
 				Type:     schema.TypeList,
 				Required: true,
-				Elem:     &schema.Schema{Type: schema.TypeString},
+				Elem: &schema.Schema{
+					Type:             schema.TypeString,
+					ValidateFunc:     ValidateCIDR(),
+					DiffSuppressFunc: suppressEquivalentCIDRDiff,
+				},
 			},
 
 			"destination_routing_registry_name": &schema.Schema{
@@ -106,15 +117,19 @@ func resourceEriPortToAzureMicrosoftConnectionV1() *schema.Resource {
 			},
 
 			"primary_connected_network_address": &schema.Schema{
-				Type:     schema.TypeString,
-				Required: true,
-				ForceNew: true,
+				Type:             schema.TypeString,
+				Required:         true,
+				ForceNew:         true,
+				ValidateFunc:     ValidateCIDR(),
+				DiffSuppressFunc: suppressEquivalentCIDRDiff,
 			},
 
 			"secondary_connected_network_address": &schema.Schema{
-				Type:     schema.TypeString,
-				Required: true,
-				ForceNew: true,
+				Type:             schema.TypeString,
+				Required:         true,
+				ForceNew:         true,
+				ValidateFunc:     ValidateCIDR(),
+				DiffSuppressFunc: suppressEquivalentCIDRDiff,
 			},
 
 			"bandwidth": &schema.Schema{
@@ -127,6 +142,7 @@ func resourceEriPortToAzureMicrosoftConnectionV1() *schema.Resource {
 					"1G", "2G", "3G", "4G", "5G",
 					"10G",
 				}, false),
+				DiffSuppressFunc: suppressEquivalentBandwidthDiff,
 			},
 
 			"redundant": &schema.Schema{
@@ -149,14 +165,47 @@ func resourceEriPortToAzureMicrosoftConnectionV1() *schema.Resource {
 				Computed: true,
 			},
 
+			"recreate_on_error": &schema.Schema{
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  false,
+			},
+
+			"adopt_existing": &schema.Schema{
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  false,
+			},
+
 			"operation_status": &schema.Schema{
 				Type:     schema.TypeString,
 				Computed: true,
 			},
+
+			"created_at": &schema.Schema{
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+
+			"raw_json": &schema.Schema{
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+
+			"uri": &schema.Schema{
+				Type:     schema.TypeString,
+				Computed: true,
+			},
 		},
 	}
 }
 
+func resourceEriPortToAzureMicrosoftConnectionV1CustomizeDiff(d *schema.ResourceDiff, meta interface{}) error {
+	warnReplacedASNs(d, "source_asn")
+
+	return customizeDiffRecreateOnError(d, meta)
+}
+
 func resourceEriPortToAzureMicrosoftConnectionV1Create(d *schema.ResourceData, meta interface{}) error {
 	config := meta.(*Config)
 	client, err := config.eriV1Client(GetRegion(d, config))
@@ -164,6 +213,10 @@ func resourceEriPortToAzureMicrosoftConnectionV1Create(d *schema.ResourceData, m
 		return fmt.Errorf("Error creating FIC ERI client: %s", err)
 	}
 
+	if adopted, err := adoptExistingConnection(d, meta, "port_to_azure_microsoft", resourceEriPortToAzureMicrosoftConnectionV1()); err != nil || adopted {
+		return err
+	}
+
 	primary := connections.Primary{
 		PortID: d.Get("source_primary_port_id").(string),
 		VLAN:   d.Get("source_primary_vlan").(int),
@@ -206,32 +259,27 @@ func resourceEriPortToAzureMicrosoftConnectionV1Create(d *schema.ResourceData, m
 	log.Printf("[DEBUG] Create Options: %#v", createOpts)
 	r, err := connections.Create(client, createOpts).Extract()
 	if err != nil {
-		return fmt.Errorf("Error creating FIC ERI port to azure microsoft connection: %s", err)
+		return formatFICError(err, "Error creating FIC ERI port to azure microsoft connection")
 	}
 
 	d.SetId(r.ID)
 
 	log.Printf("[INFO] Connection ID: %s", r.ID)
 
-	log.Printf(
-		"[DEBUG] Waiting for port to azure microsoft connection (%s) to become available", r.ID)
-
-	stateConf := &resource.StateChangeConf{
-		Pending:    []string{"Processing"},
-		Target:     []string{"Completed"},
-		Refresh:    resourcePortToAzureMicrosoftConnectionV1StateRefreshFunc(client, r.ID),
-		Timeout:    d.Timeout(schema.TimeoutCreate),
-		Delay:      10 * time.Second,
-		MinTimeout: 3 * time.Second,
-	}
-
-	_, err = stateConf.WaitForState()
+	_, err = config.waitForActive(statusWait{
+		ResourceType: "connection",
+		ID:           r.ID,
+		Refresh:      resourcePortToAzureMicrosoftConnectionV1StateRefreshFunc(client, r.ID),
+		Pending:      []string{"Processing"},
+		Target:       []string{"Completed"},
+		Timeout:      d.Timeout(schema.TimeoutCreate),
+	})
 	if err != nil {
 		return fmt.Errorf(
 			"Error waiting for port to azure microsoft connection (%s) to become ready: %s", r.ID, err)
 	}
 
-	return resourceEriPortToAzureMicrosoftConnectionV1Read(d, meta)
+	return readAfterCreate(d, meta, resourceEriPortToAzureMicrosoftConnectionV1Read)
 }
 
 func resourceEriPortToAzureMicrosoftConnectionV1Read(d *schema.ResourceData, meta interface{}) error {
@@ -248,6 +296,7 @@ func resourceEriPortToAzureMicrosoftConnectionV1Read(d *schema.ResourceData, met
 
 	log.Printf("[DEBUG] Retrieved port to azure microsoft connection %s: %+v", d.Id(), r)
 
+	d.Set("uri", client.ServiceURL("port-to-azure-microsoft-connections", d.Id()))
 	d.Set("name", r.Name)
 
 	d.Set("source_primary_port_id", r.Source.Primary.PortID)
@@ -271,8 +320,14 @@ func resourceEriPortToAzureMicrosoftConnectionV1Read(d *schema.ResourceData, met
 	d.Set("bandwidth", r.Bandwidth)
 	d.Set("redundant", r.Redundant)
 	d.Set("tenant_id", r.TenantID)
+	d.Set("operation_status", r.OperationStatus)
+	warnOnOperationError(d, r.OperationStatus)
 	d.Set("area", r.Area)
 
+	if err := setRawJSON(d, config, r); err != nil {
+		return err
+	}
+
 	return nil
 }
 
@@ -300,23 +355,17 @@ func resourceEriPortToAzureMicrosoftConnectionV1Update(d *schema.ResourceData, m
 
 		_, err := connections.Update(client, d.Id(), updateOpts).Extract()
 		if err != nil {
-			return fmt.Errorf("Error updating FIC ERI port to azure microsoft connection: %s", err)
-		}
-
-		log.Printf(
-			"[DEBUG] Waiting for port to azure microsoft connection (%s) to become complete", d.Id())
-
-		stateConf := &resource.StateChangeConf{
-			Pending:    []string{"Processing"},
-			Target:     []string{"Completed"},
-			Refresh:    resourcePortToAzureMicrosoftConnectionV1StateRefreshFunc(client, d.Id()),
-			Timeout:    d.Timeout(schema.TimeoutCreate),
-			Delay:      10 * time.Second,
-			MinTimeout: 3 * time.Second,
+			return formatFICError(err, "Error updating FIC ERI port to azure microsoft connection")
 		}
 
-		log.Printf("[DEBUG] Waiting for port to azure microsoft connection (%s) to become complete", d.Id())
-		_, err = stateConf.WaitForState()
+		_, err = waitForStatus(config.StopContext(), statusWait{
+			ResourceType: "connection",
+			ID:           d.Id(),
+			Refresh:      resourcePortToAzureMicrosoftConnectionV1StateRefreshFunc(client, d.Id()),
+			Pending:      []string{"Processing"},
+			Target:       []string{"Completed"},
+			Timeout:      d.Timeout(schema.TimeoutUpdate),
+		})
 		if err != nil {
 			return fmt.Errorf("Error waiting for port to azure microsoft connection (%s) to become complete: %s", d.Id(), err)
 		}
@@ -336,18 +385,14 @@ func resourceEriPortToAzureMicrosoftConnectionV1Delete(d *schema.ResourceData, m
 		return CheckDeleted(d, err, "connection")
 	}
 
-	log.Printf("[DEBUG] Waiting for port to azure microsoft connection (%s) to delete", d.Id())
-
-	stateConf := &resource.StateChangeConf{
-		Pending:    []string{"Processing", "Completed"},
-		Target:     []string{"Deleted"},
-		Refresh:    resourcePortToAzureMicrosoftConnectionV1StateRefreshFunc(client, d.Id()),
-		Timeout:    d.Timeout(schema.TimeoutDelete),
-		Delay:      10 * time.Second,
-		MinTimeout: 3 * time.Second,
-	}
-
-	_, err = stateConf.WaitForState()
+	_, err = waitForStatus(config.StopContext(), statusWait{
+		ResourceType: "connection",
+		ID:           d.Id(),
+		Refresh:      resourcePortToAzureMicrosoftConnectionV1StateRefreshFunc(client, d.Id()),
+		Pending:      []string{"Processing", "Completed"},
+		Target:       []string{"Deleted"},
+		Timeout:      d.Timeout(schema.TimeoutDelete),
+	})
 	if err != nil {
 		return fmt.Errorf(
 			"Error waiting for port to azure microsoft connection (%s) to delete: %s",
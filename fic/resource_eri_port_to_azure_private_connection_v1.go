@@ -16,21 +16,26 @@ func resourceEriPortToAzurePrivateConnectionV1() *schema.Resource {
 	return &schema.Resource{
 		Create: resourceEriPortToAzurePrivateConnectionV1Create,
 		Read:   resourceEriPortToAzurePrivateConnectionV1Read,
+		Update: resourceEriPortToAzurePrivateConnectionV1Update,
 		Delete: resourceEriPortToAzurePrivateConnectionV1Delete,
 		Importer: &schema.ResourceImporter{
 			State: schema.ImportStatePassthrough,
 		},
 
+		CustomizeDiff: resourceEriPortToAzurePrivateConnectionV1CustomizeDiff,
+
 		Timeouts: &schema.ResourceTimeout{
 			Create: schema.DefaultTimeout(10 * time.Minute),
+			Update: schema.DefaultTimeout(10 * time.Minute),
 			Delete: schema.DefaultTimeout(10 * time.Minute),
 		},
 
 		Schema: map[string]*schema.Schema{
 			"name": &schema.Schema{
-				Type:     schema.TypeString,
-				Required: true,
-				ForceNew: true,
+				Type:         schema.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				ValidateFunc: ValidateFICName(),
 			},
 
 			"source_primary_port_id": &schema.Schema{
@@ -58,9 +63,10 @@ func resourceEriPortToAzurePrivateConnectionV1() *schema.Resource {
 			},
 
 			"source_asn": &schema.Schema{
-				Type:     schema.TypeString,
-				Required: true,
-				ForceNew: true,
+				Type:         schema.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				ValidateFunc: ValidateASN(),
 			},
 
 			"destination_interconnect": &schema.Schema{
@@ -78,27 +84,33 @@ func resourceEriPortToAzurePrivateConnectionV1() *schema.Resource {
 			},
 
 			"destination_service_key": &schema.Schema{
-				Type:     schema.TypeString,
-				Required: true,
-				ForceNew: true,
+				Type:      schema.TypeString,
+				Required:  true,
+				ForceNew:  true,
+				Sensitive: true,
 			},
 
 			"destination_shared_key": &schema.Schema{
-				Type:     schema.TypeString,
-				Optional: true,
-				ForceNew: true,
+				Type:      schema.TypeString,
+				Optional:  true,
+				ForceNew:  true,
+				Sensitive: true,
 			},
 
 			"primary_connected_network_address": &schema.Schema{
-				Type:     schema.TypeString,
-				Required: true,
-				ForceNew: true,
+				Type:             schema.TypeString,
+				Required:         true,
+				ForceNew:         true,
+				ValidateFunc:     ValidateCIDR(),
+				DiffSuppressFunc: suppressEquivalentCIDRDiff,
 			},
 
 			"secondary_connected_network_address": &schema.Schema{
-				Type:     schema.TypeString,
-				Required: true,
-				ForceNew: true,
+				Type:             schema.TypeString,
+				Required:         true,
+				ForceNew:         true,
+				ValidateFunc:     ValidateCIDR(),
+				DiffSuppressFunc: suppressEquivalentCIDRDiff,
 			},
 
 			"bandwidth": &schema.Schema{
@@ -111,6 +123,7 @@ func resourceEriPortToAzurePrivateConnectionV1() *schema.Resource {
 					"1G", "2G", "3G", "4G", "5G",
 					"10G",
 				}, false),
+				DiffSuppressFunc: suppressEquivalentBandwidthDiff,
 			},
 
 			"redundant": &schema.Schema{
@@ -133,14 +146,47 @@ func resourceEriPortToAzurePrivateConnectionV1() *schema.Resource {
 				Computed: true,
 			},
 
+			"recreate_on_error": &schema.Schema{
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  false,
+			},
+
+			"adopt_existing": &schema.Schema{
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  false,
+			},
+
 			"operation_status": &schema.Schema{
 				Type:     schema.TypeString,
 				Computed: true,
 			},
+
+			"created_at": &schema.Schema{
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+
+			"raw_json": &schema.Schema{
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+
+			"uri": &schema.Schema{
+				Type:     schema.TypeString,
+				Computed: true,
+			},
 		},
 	}
 }
 
+func resourceEriPortToAzurePrivateConnectionV1CustomizeDiff(d *schema.ResourceDiff, meta interface{}) error {
+	warnReplacedASNs(d, "source_asn")
+
+	return customizeDiffRecreateOnError(d, meta)
+}
+
 func resourceEriPortToAzurePrivateConnectionV1Create(d *schema.ResourceData, meta interface{}) error {
 	config := meta.(*Config)
 	client, err := config.eriV1Client(GetRegion(d, config))
@@ -148,6 +194,10 @@ func resourceEriPortToAzurePrivateConnectionV1Create(d *schema.ResourceData, met
 		return fmt.Errorf("Error creating FIC ERI client: %s", err)
 	}
 
+	if adopted, err := adoptExistingConnection(d, meta, "port_to_azure_private", resourceEriPortToAzurePrivateConnectionV1()); err != nil || adopted {
+		return err
+	}
+
 	primary := connections.Primary{
 		PortID: d.Get("source_primary_port_id").(string),
 		VLAN:   d.Get("source_primary_vlan").(int),
@@ -183,32 +233,27 @@ func resourceEriPortToAzurePrivateConnectionV1Create(d *schema.ResourceData, met
 	log.Printf("[DEBUG] Create Options: %#v", createOpts)
 	r, err := connections.Create(client, createOpts).Extract()
 	if err != nil {
-		return fmt.Errorf("Error creating FIC ERI port to azure private connection: %s", err)
+		return formatFICError(err, "Error creating FIC ERI port to azure private connection")
 	}
 
 	d.SetId(r.ID)
 
 	log.Printf("[INFO] Connection ID: %s", r.ID)
 
-	log.Printf(
-		"[DEBUG] Waiting for port to azure private connection (%s) to become available", r.ID)
-
-	stateConf := &resource.StateChangeConf{
-		Pending:    []string{"Processing"},
-		Target:     []string{"Completed"},
-		Refresh:    resourcePortToAzurePrivateConnectionV1StateRefreshFunc(client, r.ID),
-		Timeout:    d.Timeout(schema.TimeoutCreate),
-		Delay:      10 * time.Second,
-		MinTimeout: 3 * time.Second,
-	}
-
-	_, err = stateConf.WaitForState()
+	_, err = config.waitForActive(statusWait{
+		ResourceType: "connection",
+		ID:           r.ID,
+		Refresh:      resourcePortToAzurePrivateConnectionV1StateRefreshFunc(client, r.ID),
+		Pending:      []string{"Processing"},
+		Target:       []string{"Completed"},
+		Timeout:      d.Timeout(schema.TimeoutCreate),
+	})
 	if err != nil {
 		return fmt.Errorf(
 			"Error waiting for port to azure private connection (%s) to become ready: %s", r.ID, err)
 	}
 
-	return resourceEriPortToAzurePrivateConnectionV1Read(d, meta)
+	return readAfterCreate(d, meta, resourceEriPortToAzurePrivateConnectionV1Read)
 }
 
 func resourceEriPortToAzurePrivateConnectionV1Read(d *schema.ResourceData, meta interface{}) error {
@@ -225,6 +270,7 @@ func resourceEriPortToAzurePrivateConnectionV1Read(d *schema.ResourceData, meta
 
 	log.Printf("[DEBUG] Retrieved port to azure private connection %s: %+v", d.Id(), r)
 
+	d.Set("uri", client.ServiceURL("port-to-azure-private-connections", d.Id()))
 	d.Set("name", r.Name)
 
 	d.Set("source_primary_port_id", r.Source.Primary.PortID)
@@ -246,11 +292,23 @@ func resourceEriPortToAzurePrivateConnectionV1Read(d *schema.ResourceData, meta
 	d.Set("bandwidth", r.Bandwidth)
 	d.Set("redundant", r.Redundant)
 	d.Set("tenant_id", r.TenantID)
+	d.Set("operation_status", r.OperationStatus)
+	warnOnOperationError(d, r.OperationStatus)
 	d.Set("area", r.Area)
 
+	if err := setRawJSON(d, config, r); err != nil {
+		return err
+	}
+
 	return nil
 }
 
+// resourceEriPortToAzurePrivateConnectionV1Update only saves
+// recreate_on_error, all other arguments force a new connection.
+func resourceEriPortToAzurePrivateConnectionV1Update(d *schema.ResourceData, meta interface{}) error {
+	return resourceEriPortToAzurePrivateConnectionV1Read(d, meta)
+}
+
 func resourceEriPortToAzurePrivateConnectionV1Delete(d *schema.ResourceData, meta interface{}) error {
 	config := meta.(*Config)
 	client, err := config.eriV1Client(GetRegion(d, config))
@@ -262,18 +320,14 @@ func resourceEriPortToAzurePrivateConnectionV1Delete(d *schema.ResourceData, met
 		return CheckDeleted(d, err, "connection")
 	}
 
-	log.Printf("[DEBUG] Waiting for port to azure private connection (%s) to delete", d.Id())
-
-	stateConf := &resource.StateChangeConf{
-		Pending:    []string{"Processing", "Completed"},
-		Target:     []string{"Deleted"},
-		Refresh:    resourcePortToAzurePrivateConnectionV1StateRefreshFunc(client, d.Id()),
-		Timeout:    d.Timeout(schema.TimeoutDelete),
-		Delay:      10 * time.Second,
-		MinTimeout: 3 * time.Second,
-	}
-
-	_, err = stateConf.WaitForState()
+	_, err = waitForStatus(config.StopContext(), statusWait{
+		ResourceType: "connection",
+		ID:           d.Id(),
+		Refresh:      resourcePortToAzurePrivateConnectionV1StateRefreshFunc(client, d.Id()),
+		Pending:      []string{"Processing", "Completed"},
+		Target:       []string{"Deleted"},
+		Timeout:      d.Timeout(schema.TimeoutDelete),
+	})
 	if err != nil {
 		return fmt.Errorf(
 			"Error waiting for port to azure private connection (%s) to delete: %s",
@@ -11,27 +11,33 @@ import (
 
 	"github.com/nttcom/go-fic"
 	connections "github.com/nttcom/go-fic/fic/eri/v1/port_to_port_connections"
+	"github.com/nttcom/go-fic/fic/eri/v1/ports"
 )
 
 func resourceEriPortToPortConnectionV1() *schema.Resource {
 	return &schema.Resource{
 		Create: resourceEriPortToPortConnectionV1Create,
 		Read:   resourceEriPortToPortConnectionV1Read,
+		Update: resourceEriPortToPortConnectionV1Update,
 		Delete: resourceEriPortToPortConnectionV1Delete,
 		Importer: &schema.ResourceImporter{
 			State: schema.ImportStatePassthrough,
 		},
 
+		CustomizeDiff: resourceEriPortToPortConnectionV1CustomizeDiff,
+
 		Timeouts: &schema.ResourceTimeout{
 			Create: schema.DefaultTimeout(10 * time.Minute),
+			Update: schema.DefaultTimeout(10 * time.Minute),
 			Delete: schema.DefaultTimeout(10 * time.Minute),
 		},
 
 		Schema: map[string]*schema.Schema{
 			"name": &schema.Schema{
-				Type:     schema.TypeString,
-				Required: true,
-				ForceNew: true,
+				Type:         schema.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				ValidateFunc: ValidateFICName(),
 			},
 
 			"source_port_id": &schema.Schema{
@@ -66,6 +72,7 @@ func resourceEriPortToPortConnectionV1() *schema.Resource {
 					"10M", "20M", "30M", "40M", "50M", "100M", "200M", "300M", "400M", "500M",
 					"1G", "2G", "3G", "4G", "5G", "10G",
 				}, false),
+				DiffSuppressFunc: suppressEquivalentBandwidthDiff,
 			},
 
 			"redundant": &schema.Schema{
@@ -78,14 +85,112 @@ func resourceEriPortToPortConnectionV1() *schema.Resource {
 				Computed: true,
 			},
 
+			"recreate_on_error": &schema.Schema{
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  false,
+			},
+
+			"adopt_existing": &schema.Schema{
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  false,
+			},
+
+			"operation_status": &schema.Schema{
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+
+			"created_at": &schema.Schema{
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+
 			"area": &schema.Schema{
 				Type:     schema.TypeString,
 				Computed: true,
 			},
+
+			"raw_json": &schema.Schema{
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+
+			"uri": &schema.Schema{
+				Type:     schema.TypeString,
+				Computed: true,
+			},
 		},
 	}
 }
 
+// resourceEriPortToPortConnectionV1CustomizeDiff rejects a connection whose
+// source and destination are the same VLAN of the same port, or whose VLAN
+// is not one of those allocated to its port. The checks are skipped while
+// any of the values is unknown. A connection in the Error state is handled
+// by customizeDiffRecreateOnError first.
+func resourceEriPortToPortConnectionV1CustomizeDiff(d *schema.ResourceDiff, meta interface{}) error {
+	if err := customizeDiffRecreateOnError(d, meta); err != nil {
+		return err
+	}
+
+	for _, k := range []string{"source_port_id", "source_vlan", "destination_port_id", "destination_vlan"} {
+		if !d.NewValueKnown(k) {
+			return nil
+		}
+	}
+
+	sourcePortID := d.Get("source_port_id").(string)
+	sourceVLAN := d.Get("source_vlan").(int)
+	destinationPortID := d.Get("destination_port_id").(string)
+	destinationVLAN := d.Get("destination_vlan").(int)
+
+	if sourcePortID == destinationPortID && sourceVLAN == destinationVLAN {
+		return fmt.Errorf(
+			"source and destination of the connection must differ: "+
+				"source_port_id %s and destination_port_id %s both use VLAN %d",
+			sourcePortID, destinationPortID, sourceVLAN)
+	}
+
+	// The ports are only looked up when a new connection is planned, and
+	// not at all when validating without a configured provider.
+	config, ok := meta.(*Config)
+	if !ok || d.Id() != "" && !d.HasChange("source_port_id") && !d.HasChange("source_vlan") &&
+		!d.HasChange("destination_port_id") && !d.HasChange("destination_vlan") {
+		return nil
+	}
+
+	client, err := config.eriV1Client(GetRegion(d, config))
+	if err != nil {
+		return fmt.Errorf("Error creating FIC ERI client: %s", err)
+	}
+
+	if err := checkPortVLAN(client, "source_vlan", sourcePortID, sourceVLAN); err != nil {
+		return err
+	}
+
+	return checkPortVLAN(client, "destination_vlan", destinationPortID, destinationVLAN)
+}
+
+// checkPortVLAN returns an error if vlan is not in the VLAN ranges of the
+// port. A connection that is replaced still holds its VLAN, so the status
+// of the VLAN is not checked.
+func checkPortVLAN(client *fic.ServiceClient, key, portID string, vlan int) error {
+	p, err := ports.Get(client, portID).Extract()
+	if err != nil {
+		return formatFICError(err, fmt.Sprintf("Error retrieving FIC ERI port %s", portID))
+	}
+
+	for _, v := range p.VLANs {
+		if v.VID == vlan {
+			return nil
+		}
+	}
+
+	return fmt.Errorf("%s %d is not in the VLAN ranges of port %s", key, vlan, portID)
+}
+
 func resourceEriPortToPortConnectionV1Create(d *schema.ResourceData, meta interface{}) error {
 	config := meta.(*Config)
 	client, err := config.eriV1Client(GetRegion(d, config))
@@ -93,6 +198,10 @@ func resourceEriPortToPortConnectionV1Create(d *schema.ResourceData, meta interf
 		return fmt.Errorf("Error creating FIC ERI client: %s", err)
 	}
 
+	if adopted, err := adoptExistingConnection(d, meta, "port_to_port", resourceEriPortToPortConnectionV1()); err != nil || adopted {
+		return err
+	}
+
 	source := connections.Source{
 		PortID: d.Get("source_port_id").(string),
 		VLAN:   d.Get("source_vlan").(int),
@@ -113,32 +222,27 @@ func resourceEriPortToPortConnectionV1Create(d *schema.ResourceData, meta interf
 	log.Printf("[DEBUG] Create Options: %#v", createOpts)
 	r, err := connections.Create(client, createOpts).Extract()
 	if err != nil {
-		return fmt.Errorf("Error creating FIC ERI connection(port to port): %s", err)
+		return formatFICError(err, "Error creating FIC ERI connection(port to port)")
 	}
 
 	d.SetId(r.ID)
 
 	log.Printf("[INFO] Connection ID: %s", r.ID)
 
-	log.Printf(
-		"[DEBUG] Waiting for connection (%s) to become available", r.ID)
-
-	stateConf := &resource.StateChangeConf{
-		Pending:    []string{"Processing"},
-		Target:     []string{"Completed"},
-		Refresh:    PortToPortConnectionV1StateRefreshFunc(client, r.ID),
-		Timeout:    d.Timeout(schema.TimeoutCreate),
-		Delay:      10 * time.Second,
-		MinTimeout: 3 * time.Second,
-	}
-
-	_, err = stateConf.WaitForState()
+	_, err = config.waitForActive(statusWait{
+		ResourceType: "connection",
+		ID:           r.ID,
+		Refresh:      PortToPortConnectionV1StateRefreshFunc(client, r.ID),
+		Pending:      []string{"Processing"},
+		Target:       []string{"Completed"},
+		Timeout:      d.Timeout(schema.TimeoutCreate),
+	})
 	if err != nil {
 		return fmt.Errorf(
 			"Error waiting for connection (%s) to become ready: %s", r.ID, err)
 	}
 
-	return resourceEriPortToPortConnectionV1Read(d, meta)
+	return readAfterCreate(d, meta, resourceEriPortToPortConnectionV1Read)
 }
 
 func resourceEriPortToPortConnectionV1Read(d *schema.ResourceData, meta interface{}) error {
@@ -155,6 +259,7 @@ func resourceEriPortToPortConnectionV1Read(d *schema.ResourceData, meta interfac
 
 	log.Printf("[DEBUG] Retrieved connection %s: %+v", d.Id(), r)
 
+	d.Set("uri", client.ServiceURL("port-to-port-connections", d.Id()))
 	d.Set("name", r.Name)
 
 	d.Set("source_port_id", r.Source.PortID)
@@ -166,11 +271,23 @@ func resourceEriPortToPortConnectionV1Read(d *schema.ResourceData, meta interfac
 	d.Set("bandwidth", r.Bandwidth)
 	d.Set("redundant", r.Redundant)
 	d.Set("tenant_id", r.TenantID)
+	d.Set("operation_status", r.OperationStatus)
+	warnOnOperationError(d, r.OperationStatus)
 	d.Set("area", r.Area)
 
+	if err := setRawJSON(d, config, r); err != nil {
+		return err
+	}
+
 	return nil
 }
 
+// resourceEriPortToPortConnectionV1Update only saves recreate_on_error, all
+// other arguments force a new connection.
+func resourceEriPortToPortConnectionV1Update(d *schema.ResourceData, meta interface{}) error {
+	return resourceEriPortToPortConnectionV1Read(d, meta)
+}
+
 func resourceEriPortToPortConnectionV1Delete(d *schema.ResourceData, meta interface{}) error {
 	config := meta.(*Config)
 	client, err := config.eriV1Client(GetRegion(d, config))
@@ -182,18 +299,14 @@ func resourceEriPortToPortConnectionV1Delete(d *schema.ResourceData, meta interf
 		return CheckDeleted(d, err, "connection")
 	}
 
-	log.Printf("[DEBUG] Waiting for connection (%s) to delete", d.Id())
-
-	stateConf := &resource.StateChangeConf{
-		Pending:    []string{"Processing", "Completed"},
-		Target:     []string{"Deleted"},
-		Refresh:    PortToPortConnectionV1StateRefreshFunc(client, d.Id()),
-		Timeout:    d.Timeout(schema.TimeoutDelete),
-		Delay:      10 * time.Second,
-		MinTimeout: 3 * time.Second,
-	}
-
-	_, err = stateConf.WaitForState()
+	_, err = waitForStatus(config.StopContext(), statusWait{
+		ResourceType: "connection",
+		ID:           d.Id(),
+		Refresh:      PortToPortConnectionV1StateRefreshFunc(client, d.Id()),
+		Pending:      []string{"Processing", "Completed"},
+		Target:       []string{"Deleted"},
+		Timeout:      d.Timeout(schema.TimeoutDelete),
+	})
 	if err != nil {
 		return fmt.Errorf(
 			"Error waiting for connection (%s) to delete: %s",
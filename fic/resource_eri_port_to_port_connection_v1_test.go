@@ -1,16 +1,77 @@
 package fic
 
 import (
+	"bytes"
+	"encoding/json"
 	"fmt"
+	"log"
+	"net/http"
+	"os"
+	"reflect"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/hashicorp/terraform-plugin-sdk/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/terraform"
 
 	connections "github.com/nttcom/go-fic/fic/eri/v1/port_to_port_connections"
 	"github.com/nttcom/go-fic/fic/eri/v1/ports"
 )
 
+// testUnknownVariableValue is the placeholder Terraform uses in a raw config
+// for values that are not known until apply. It mirrors
+// hcl2shim.UnknownVariableValue, which SDK v1 keeps in an internal package.
+const testUnknownVariableValue = "74D93920-ED26-11E3-AC10-0800200C9A66"
+
+func TestResourceEriPortToPortConnectionV1CustomizeDiff(t *testing.T) {
+	cases := []struct {
+		config   map[string]interface{}
+		errMatch string
+	}{
+		{
+			config:   testPortToPortConnectionV1RawConfig("F010123456789", 1137, "F010123456789", 1137),
+			errMatch: "source_port_id F010123456789 and destination_port_id F010123456789 both use VLAN 1137",
+		},
+		{
+			config: testPortToPortConnectionV1RawConfig("F010123456789", 1137, "F010123456789", 1138),
+		},
+		{
+			config: testPortToPortConnectionV1RawConfig("F010123456789", 1137, "F019876543210", 1137),
+		},
+		{
+			config: testPortToPortConnectionV1RawConfig("F010123456789", 1137, testUnknownVariableValue, 1137),
+		},
+	}
+
+	r := resourceEriPortToPortConnectionV1()
+	for i, tc := range cases {
+		_, err := r.Diff(nil, terraform.NewResourceConfigRaw(tc.config), nil)
+		if tc.errMatch == "" {
+			if err != nil {
+				t.Errorf("case %d: unexpected error: %s", i, err)
+			}
+			continue
+		}
+
+		if err == nil || !strings.Contains(err.Error(), tc.errMatch) {
+			t.Errorf("case %d: expected an error containing %q, got %v", i, tc.errMatch, err)
+		}
+	}
+}
+
+func testPortToPortConnectionV1RawConfig(sourcePortID string, sourceVLAN int, destinationPortID string, destinationVLAN int) map[string]interface{} {
+	return map[string]interface{}{
+		"name":                "terraform_connection_1",
+		"source_port_id":      sourcePortID,
+		"source_vlan":         sourceVLAN,
+		"destination_port_id": destinationPortID,
+		"destination_vlan":    destinationVLAN,
+		"bandwidth":           "10M",
+	}
+}
+
 func TestAccEriPortToPortConnectionV1Basic(t *testing.T) {
 	var p1, p2 ports.Port
 	var c connections.Connection
@@ -132,3 +193,359 @@ resource "fic_eri_port_to_port_connection_v1" "connection_1" {
 	OS_SWITCH_NAME,
 	OS_SWITCH_NAME,
 )
+
+func TestResourceEriPortToPortConnectionV1CreateWithoutWait(t *testing.T) {
+	gets := 0
+	config, closeServer := testMockConfig(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodGet && r.URL.Path != "/v1/operations" {
+			gets++
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		if r.Method == http.MethodPost {
+			w.WriteHeader(http.StatusAccepted)
+		}
+		fmt.Fprint(w, `{"connection": {"id": "F030123456789", "name": "terraform_connection_1", "bandwidth": "10M", "operationStatus": "Processing"}}`)
+	}))
+	defer closeServer()
+
+	config.SkipWaitForActive = true
+
+	r := resourceEriPortToPortConnectionV1()
+	d := schema.TestResourceDataRaw(t, r.Schema, testPortToPortConnectionV1RawConfig("F010123456789", 1137, "F019876543210", 1137))
+
+	start := time.Now()
+	if err := resourceEriPortToPortConnectionV1Create(d, config); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	// The state change configuration delays its first poll by 10 seconds.
+	if elapsed := time.Since(start); elapsed > 5*time.Second {
+		t.Fatalf("expected Create to return without polling, took %s", elapsed)
+	}
+
+	if gets != 1 {
+		t.Fatalf("expected only the read after create, got %d GET requests", gets)
+	}
+
+	if v := d.Get("operation_status").(string); v != "Processing" {
+		t.Fatalf("expected operation_status Processing, got %q", v)
+	}
+}
+
+func TestResourceEriPortToPortConnectionV1CustomizeDiffVLANRange(t *testing.T) {
+	config, closeServer := testMockConfig(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		id := r.URL.Path[strings.LastIndex(r.URL.Path, "/")+1:]
+		fmt.Fprintf(w, `{"port": {"id": %q, "vlans": [{"vid": 1137, "status": "used"}, {"vid": 1138, "status": "unused"}]}}`, id)
+	}))
+	defer closeServer()
+
+	cases := []struct {
+		config   map[string]interface{}
+		errMatch string
+	}{
+		{
+			config: testPortToPortConnectionV1RawConfig("F010123456789", 1137, "F019876543210", 1138),
+		},
+		{
+			config:   testPortToPortConnectionV1RawConfig("F010123456789", 1101, "F019876543210", 1138),
+			errMatch: "source_vlan 1101 is not in the VLAN ranges of port F010123456789",
+		},
+		{
+			config:   testPortToPortConnectionV1RawConfig("F010123456789", 1137, "F019876543210", 1200),
+			errMatch: "destination_vlan 1200 is not in the VLAN ranges of port F019876543210",
+		},
+	}
+
+	r := resourceEriPortToPortConnectionV1()
+	for i, tc := range cases {
+		_, err := r.Diff(nil, terraform.NewResourceConfigRaw(tc.config), config)
+		if tc.errMatch == "" {
+			if err != nil {
+				t.Errorf("case %d: unexpected error: %s", i, err)
+			}
+			continue
+		}
+
+		if err == nil || !strings.Contains(err.Error(), tc.errMatch) {
+			t.Errorf("case %d: expected an error containing %q, got %v", i, tc.errMatch, err)
+		}
+	}
+}
+
+func TestResourceEriPortToPortConnectionV1CreateRequestBody(t *testing.T) {
+	var body map[string]interface{}
+	config, closeServer := testMockConfig(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		if r.Method == http.MethodPost {
+			if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+				t.Errorf("unexpected error decoding the request body: %s", err)
+			}
+			w.WriteHeader(http.StatusAccepted)
+		}
+		fmt.Fprint(w, `{"connection": {"id": "F030123456789", "operationStatus": "Processing"}}`)
+	}))
+	defer closeServer()
+
+	config.SkipWaitForActive = true
+
+	r := resourceEriPortToPortConnectionV1()
+	d := schema.TestResourceDataRaw(t, r.Schema, testPortToPortConnectionV1RawConfig("F010123456789", 1137, "F019876543210", 1138))
+	if err := resourceEriPortToPortConnectionV1Create(d, config); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	expected := map[string]interface{}{
+		"connection": map[string]interface{}{
+			"name":        "terraform_connection_1",
+			"source":      map[string]interface{}{"portId": "F010123456789", "vlan": float64(1137)},
+			"destination": map[string]interface{}{"portId": "F019876543210", "vlan": float64(1138)},
+			"bandwidth":   "10M",
+		},
+	}
+	if !reflect.DeepEqual(body, expected) {
+		t.Fatalf("expected request body %#v, got %#v", expected, body)
+	}
+}
+
+func TestResourceEriPortToPortConnectionV1RecreateOnError(t *testing.T) {
+	config, closeServer := testMockConfig(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		fmt.Fprint(w, `{"connection": {"id": "F030123456789", "name": "terraform_connection_1",
+			"source": {"portId": "F010123456789", "vlan": 1137},
+			"destination": {"portId": "F019876543210", "vlan": 1137},
+			"bandwidth": "10M", "operationStatus": "Error"}}`)
+	}))
+	defer closeServer()
+
+	var buf bytes.Buffer
+	log.SetOutput(&buf)
+	defer log.SetOutput(os.Stderr)
+
+	r := resourceEriPortToPortConnectionV1()
+	for _, recreate := range []bool{false, true} {
+		raw := testPortToPortConnectionV1RawConfig("F010123456789", 1137, "F019876543210", 1137)
+		raw["recreate_on_error"] = recreate
+
+		d := schema.TestResourceDataRaw(t, r.Schema, raw)
+		d.SetId("F030123456789")
+		buf.Reset()
+		if err := resourceEriPortToPortConnectionV1Read(d, config); err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+
+		if !strings.Contains(buf.String(), "[WARN] Connection F030123456789 is in Error state") {
+			t.Fatalf("expected a warning about the Error state, got %q", buf.String())
+		}
+
+		diff, err := r.Diff(d.State(), terraform.NewResourceConfigRaw(raw), nil)
+		if err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+
+		if requiresNew := diff != nil && diff.RequiresNew(); requiresNew != recreate {
+			t.Fatalf("recreate_on_error %t: expected replacement %t, got %t", recreate, recreate, requiresNew)
+		}
+	}
+}
+
+func TestResourceEriPortToPortConnectionV1CreateReadNotFound(t *testing.T) {
+	defer func(timeout, interval time.Duration) {
+		readAfterCreateTimeout, readAfterCreateInterval = timeout, interval
+	}(readAfterCreateTimeout, readAfterCreateInterval)
+	readAfterCreateTimeout, readAfterCreateInterval = time.Second, time.Millisecond
+
+	gets := 0
+	config, closeServer := testMockConfig(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch r.Method {
+		case http.MethodPost:
+			w.WriteHeader(http.StatusAccepted)
+		case http.MethodGet:
+			if r.URL.Path == "/v1/operations" {
+				break
+			}
+			gets++
+			if gets == 1 {
+				w.WriteHeader(http.StatusNotFound)
+				return
+			}
+		}
+		fmt.Fprint(w, `{"connection": {"id": "F030123456789", "name": "terraform_connection_1", "bandwidth": "10M", "operationStatus": "Processing"}}`)
+	}))
+	defer closeServer()
+
+	config.SkipWaitForActive = true
+
+	r := resourceEriPortToPortConnectionV1()
+	d := schema.TestResourceDataRaw(t, r.Schema, testPortToPortConnectionV1RawConfig("F010123456789", 1137, "F019876543210", 1137))
+
+	if err := resourceEriPortToPortConnectionV1Create(d, config); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	if d.Id() != "F030123456789" {
+		t.Fatalf("expected the ID to be retained, got %q", d.Id())
+	}
+
+	if gets != 2 {
+		t.Fatalf("expected the read to be repeated once, got %d GET requests", gets)
+	}
+}
+
+func TestResourceEriPortToPortConnectionV1CreatedAt(t *testing.T) {
+	receptionTime := "2020-07-01T09:30:00+09:00"
+	lists := 0
+	config, closeServer := testMockConfig(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		if r.URL.Path == "/v1/operations" {
+			lists++
+			fmt.Fprintf(w, `{"operations": [
+				{"id": "F052000000001", "resourceId": "F030123456789", "requestType": "Update", "receptionTime": "2020-07-02T00:00:00Z"},
+				{"id": "F052000000000", "resourceId": "F030123456789", "requestType": "Create", "receptionTime": %q}
+			]}`, receptionTime)
+			return
+		}
+		fmt.Fprint(w, `{"connection": {"id": "F030123456789", "name": "terraform_connection_1", "bandwidth": "10M", "operationStatus": "Completed"}}`)
+	}))
+	defer closeServer()
+
+	r := resourceEriPortToPortConnectionV1()
+	d := schema.TestResourceDataRaw(t, r.Schema, testPortToPortConnectionV1RawConfig("F010123456789", 1137, "F019876543210", 1137))
+	d.SetId("F030123456789")
+
+	if err := readAfterCreate(d, config, resourceEriPortToPortConnectionV1Read); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	if v := d.Get("created_at").(string); v != "2020-07-01T00:30:00Z" {
+		t.Fatalf("expected created_at 2020-07-01T00:30:00Z, got %q", v)
+	}
+
+	// The same instant in another representation is stored the same way.
+	receptionTime = "2020-07-01T00:30:00.000Z"
+	d.Set("created_at", "")
+	if err := readAfterCreate(d, config, resourceEriPortToPortConnectionV1Read); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	if v := d.Get("created_at").(string); v != "2020-07-01T00:30:00Z" {
+		t.Fatalf("expected created_at to be unchanged, got %q", v)
+	}
+
+	// Later reads keep created_at without listing the operation history.
+	if err := resourceEriPortToPortConnectionV1Read(d, config); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	if lists != 2 {
+		t.Fatalf("expected the operation history to be listed by the creates only, got %d lists", lists)
+	}
+}
+
+func TestResourceEriPortToPortConnectionV1CreatedAtNotFound(t *testing.T) {
+	var buf bytes.Buffer
+	log.SetOutput(&buf)
+	defer log.SetOutput(os.Stderr)
+
+	lists := 0
+	config, closeServer := testMockConfig(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		if r.URL.Path == "/v1/operations" {
+			lists++
+			fmt.Fprint(w, `{"operations": [
+				{"id": "F052000000001", "resourceId": "F030123456789", "requestType": "Update", "receptionTime": "2020-07-02T00:00:00Z"}
+			]}`)
+			return
+		}
+		fmt.Fprint(w, `{"connection": {"id": "F030123456789", "name": "terraform_connection_1", "bandwidth": "10M", "operationStatus": "Completed"}}`)
+	}))
+	defer closeServer()
+
+	r := resourceEriPortToPortConnectionV1()
+	d := schema.TestResourceDataRaw(t, r.Schema, testPortToPortConnectionV1RawConfig("F010123456789", 1137, "F019876543210", 1137))
+	d.SetId("F030123456789")
+
+	if err := readAfterCreate(d, config, resourceEriPortToPortConnectionV1Read); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	for i := 0; i < 2; i++ {
+		if err := resourceEriPortToPortConnectionV1Read(d, config); err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+	}
+
+	if v := d.Get("created_at").(string); v != "" {
+		t.Fatalf("expected created_at to be empty, got %q", v)
+	}
+
+	if !strings.Contains(buf.String(), "[WARN] No Create operation of connection F030123456789") {
+		t.Fatalf("expected a warning, got %q", buf.String())
+	}
+
+	if lists != 1 {
+		t.Fatalf("expected the operation history to be listed once, got %d", lists)
+	}
+}
+
+func TestResourceEriPortToPortConnectionV1CreateAdoptExisting(t *testing.T) {
+	cases := []struct {
+		bandwidth string
+		adopted   bool
+	}{
+		{"10Mbps", true},
+		{"100M", false},
+	}
+
+	for _, tc := range cases {
+		posts := 0
+		config, closeServer := testMockConfig(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Set("Content-Type", "application/json")
+			switch {
+			case r.Method == http.MethodPost:
+				posts++
+				w.WriteHeader(http.StatusAccepted)
+				fmt.Fprint(w, `{"connection": {"id": "F030000000002", "operationStatus": "Processing"}}`)
+			case r.URL.Path == "/v1/port-to-port-connections":
+				fmt.Fprint(w, `{"connections": [
+					{"id": "F030000000001", "name": "other_connection"},
+					{"id": "F030123456789", "name": "terraform_connection_1"}
+				]}`)
+			default:
+				fmt.Fprintf(w, `{"connection": {"id": "F030123456789", "name": "terraform_connection_1", "bandwidth": %q, "operationStatus": "Completed",
+					"source": {"portId": "F010123456789", "vlan": 1137}, "destination": {"portId": "F019876543210", "vlan": 1137}}}`, tc.bandwidth)
+			}
+		}))
+
+		raw := testPortToPortConnectionV1RawConfig("F010123456789", 1137, "F019876543210", 1137)
+		raw["adopt_existing"] = true
+		r := resourceEriPortToPortConnectionV1()
+		d := schema.TestResourceDataRaw(t, r.Schema, raw)
+
+		err := resourceEriPortToPortConnectionV1Create(d, config)
+		closeServer()
+
+		if posts != 0 {
+			t.Errorf("bandwidth %s: expected no connection to be created, got %d POST requests", tc.bandwidth, posts)
+		}
+
+		if tc.adopted {
+			if err != nil {
+				t.Fatalf("bandwidth %s: unexpected error: %s", tc.bandwidth, err)
+			}
+			if d.Id() != "F030123456789" {
+				t.Fatalf("bandwidth %s: expected the connection to be adopted, got ID %q", tc.bandwidth, d.Id())
+			}
+			continue
+		}
+
+		if err == nil || !strings.Contains(err.Error(), "bandwidth is 100M, configured 10M") {
+			t.Fatalf("bandwidth %s: expected a conflict error, got %v", tc.bandwidth, err)
+		}
+		if d.Id() != "" {
+			t.Fatalf("bandwidth %s: expected no ID on conflict, got %q", tc.bandwidth, d.Id())
+		}
+	}
+}
@@ -3,6 +3,9 @@ package fic
 import (
 	"fmt"
 	"log"
+	"sort"
+	"strconv"
+	"strings"
 	"time"
 
 	"github.com/hashicorp/terraform-plugin-sdk/helper/resource"
@@ -23,16 +26,20 @@ func resourceEriPortV1() *schema.Resource {
 			State: schema.ImportStatePassthrough,
 		},
 
+		CustomizeDiff: resourceEriPortV1CustomizeDiff,
+
 		Timeouts: &schema.ResourceTimeout{
 			Create: schema.DefaultTimeout(10 * time.Minute),
+			Update: schema.DefaultTimeout(10 * time.Minute),
 			Delete: schema.DefaultTimeout(10 * time.Minute),
 		},
 
 		Schema: map[string]*schema.Schema{
 			"name": &schema.Schema{
-				Type:     schema.TypeString,
-				Required: true,
-				ForceNew: true,
+				Type:         schema.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				ValidateFunc: ValidateFICName(),
 			},
 
 			"switch_name": &schema.Schema{
@@ -61,12 +68,14 @@ func resourceEriPortV1() *schema.Resource {
 				Elem: &schema.Resource{
 					Schema: map[string]*schema.Schema{
 						"start": &schema.Schema{
-							Type:     schema.TypeInt,
-							Required: true,
+							Type:         schema.TypeInt,
+							Required:     true,
+							ValidateFunc: validation.IntBetween(1, 4094),
 						},
 						"end": &schema.Schema{
-							Type:     schema.TypeInt,
-							Required: true,
+							Type:         schema.TypeInt,
+							Required:     true,
+							ValidateFunc: validation.IntBetween(1, 4094),
 						},
 					},
 				},
@@ -91,6 +100,11 @@ func resourceEriPortV1() *schema.Resource {
 				Computed: true,
 			},
 
+			"operation_status": &schema.Schema{
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+
 			"area": &schema.Schema{
 				Type:     schema.TypeString,
 				Computed: true,
@@ -117,10 +131,96 @@ func resourceEriPortV1() *schema.Resource {
 					},
 				},
 			},
+
+			"uri": &schema.Schema{
+				Type:     schema.TypeString,
+				Computed: true,
+			},
 		},
 	}
 }
 
+// portVLANCapacity is the largest number of VLANs of a port of each type,
+// the largest number_of_vlans accepted by FIC.
+var portVLANCapacity = map[string]int{
+	"1G":  512,
+	"10G": 512,
+}
+
+// resourceEriPortV1CustomizeDiff rejects vlan_ranges that are reversed or
+// overlap, or that hold more VLANs than a port of port_type can. The capacity
+// is not checked while port_type is unknown, and ranges with an unknown bound
+// are skipped.
+func resourceEriPortV1CustomizeDiff(d *schema.ResourceDiff, meta interface{}) error {
+	if err := checkVLANRanges(d); err != nil {
+		return err
+	}
+
+	if !d.NewValueKnown("port_type") {
+		return nil
+	}
+
+	portType := d.Get("port_type").(string)
+	capacity, ok := portVLANCapacity[portType]
+	if !ok {
+		return nil
+	}
+
+	total := 0
+	for i, v := range d.Get("vlan_ranges").([]interface{}) {
+		if !d.NewValueKnown(fmt.Sprintf("vlan_ranges.%d.start", i)) || !d.NewValueKnown(fmt.Sprintf("vlan_ranges.%d.end", i)) {
+			continue
+		}
+
+		r := v.(map[string]interface{})
+		if n := r["end"].(int) - r["start"].(int) + 1; n > 0 {
+			total += n
+		}
+	}
+
+	if total > capacity {
+		return fmt.Errorf("vlan_ranges hold %d VLANs, but a %s port holds at most %d", total, portType, capacity)
+	}
+
+	return nil
+}
+
+// checkVLANRanges returns an error if a range of vlan_ranges ends before it
+// starts, or if two ranges share a VLAN.
+func checkVLANRanges(d *schema.ResourceDiff) error {
+	type vlanRange struct {
+		index, start, end int
+	}
+
+	var ranges []vlanRange
+	for i, v := range d.Get("vlan_ranges").([]interface{}) {
+		if !d.NewValueKnown(fmt.Sprintf("vlan_ranges.%d.start", i)) || !d.NewValueKnown(fmt.Sprintf("vlan_ranges.%d.end", i)) {
+			continue
+		}
+
+		r := v.(map[string]interface{})
+		start, end := r["start"].(int), r["end"].(int)
+		if start > end {
+			return fmt.Errorf("vlan_ranges.%d ends at VLAN %d before it starts at VLAN %d", i, end, start)
+		}
+		ranges = append(ranges, vlanRange{i, start, end})
+	}
+
+	sort.Slice(ranges, func(i, j int) bool {
+		return ranges[i].start < ranges[j].start
+	})
+
+	for i := 1; i < len(ranges); i++ {
+		prev, cur := ranges[i-1], ranges[i]
+		if cur.start <= prev.end {
+			return fmt.Errorf("vlan_ranges.%d (%d-%d) and vlan_ranges.%d (%d-%d) overlap",
+				prev.index, prev.start, prev.end, cur.index, cur.start, cur.end)
+		}
+	}
+
+	return nil
+}
+
 func resourceEriPortV1Create(d *schema.ResourceData, meta interface{}) error {
 	config := meta.(*Config)
 	client, err := config.eriV1Client(GetRegion(d, config))
@@ -143,26 +243,21 @@ func resourceEriPortV1Create(d *schema.ResourceData, meta interface{}) error {
 	log.Printf("[DEBUG] Create Options: %#v", createOpts)
 	r, err := ports.Create(client, createOpts).Extract()
 	if err != nil {
-		return fmt.Errorf("Error creating FIC ERI port: %s", err)
+		return formatFICError(err, "Error creating FIC ERI port")
 	}
 
 	d.SetId(r.ID)
 
 	log.Printf("[INFO] Port ID: %s", r.ID)
 
-	log.Printf(
-		"[DEBUG] Waiting for port (%s) to become available", r.ID)
-
-	stateConf := &resource.StateChangeConf{
-		Pending:    []string{"Processing"},
-		Target:     []string{"Completed"},
-		Refresh:    PortV1StateRefreshFunc(client, r.ID),
-		Timeout:    d.Timeout(schema.TimeoutCreate),
-		Delay:      10 * time.Second,
-		MinTimeout: 3 * time.Second,
-	}
-
-	_, err = stateConf.WaitForState()
+	_, err = waitForStatus(config.StopContext(), statusWait{
+		ResourceType: "port",
+		ID:           r.ID,
+		Refresh:      PortV1StateRefreshFunc(client, r.ID),
+		Pending:      []string{"Processing"},
+		Target:       []string{"Completed"},
+		Timeout:      d.Timeout(schema.TimeoutCreate),
+	})
 	if err != nil {
 		return fmt.Errorf(
 			"Error waiting for port (%s) to become ready: %s", r.ID, err)
@@ -174,23 +269,17 @@ func resourceEriPortV1Create(d *schema.ResourceData, meta interface{}) error {
 	if isActivated {
 		r, err = ports.Activate(client, r.ID).Extract()
 		if err != nil {
-			return fmt.Errorf("Error activating FIC ERI port: %s", err)
-		}
-
-		log.Printf(
-			"[DEBUG] Waiting for port (%s) to become active", r.ID)
-
-		activateStateConf := &resource.StateChangeConf{
-			Pending:    []string{"Processing"},
-			Target:     []string{"Completed"},
-			Refresh:    PortV1StateRefreshFunc(client, r.ID),
-			Timeout:    d.Timeout(schema.TimeoutCreate),
-			Delay:      10 * time.Second,
-			MinTimeout: 3 * time.Second,
+			return formatFICError(err, "Error activating FIC ERI port")
 		}
 
-		log.Printf("[DEBUG] Waiting for port (%s) to become active", r.ID)
-		_, err = activateStateConf.WaitForState()
+		_, err = config.waitForActive(statusWait{
+			ResourceType: "port",
+			ID:           r.ID,
+			Refresh:      PortV1StateRefreshFunc(client, r.ID),
+			Pending:      []string{"Processing"},
+			Target:       []string{"Completed"},
+			Timeout:      d.Timeout(schema.TimeoutCreate),
+		})
 		if err != nil {
 			return fmt.Errorf("Error waiting for port (%s) to become active: %s", r.ID, err)
 		}
@@ -199,9 +288,16 @@ func resourceEriPortV1Create(d *schema.ResourceData, meta interface{}) error {
 	return resourceEriPortV1Read(d, meta)
 }
 
+// getVLANsForState returns the VLANs of the port ordered by VLAN ID.
 func getVLANsForState(r *ports.Port) []map[string]interface{} {
+	vlans := make([]ports.VLAN, len(r.VLANs))
+	copy(vlans, r.VLANs)
+	sort.Slice(vlans, func(i, j int) bool {
+		return vlans[i].VID < vlans[j].VID
+	})
+
 	var result []map[string]interface{}
-	for _, v := range r.VLANs {
+	for _, v := range vlans {
 		vid := v.VID
 		status := v.Status
 		m := map[string]interface{}{
@@ -213,6 +309,57 @@ func getVLANsForState(r *ports.Port) []map[string]interface{} {
 	return result
 }
 
+// getVLANRangesForState converts the "start-end" VLAN ranges returned by the
+// API into the vlan_ranges schema. Ranges that are already in current keep
+// their position and the remaining ones follow in ascending order, so the
+// order of the API response never causes a diff.
+func getVLANRangesForState(ranges []string, current []interface{}) ([]map[string]interface{}, error) {
+	var parsed []map[string]interface{}
+	for _, vr := range ranges {
+		vlans := strings.Split(vr, "-")
+		if len(vlans) != 2 {
+			return nil, fmt.Errorf("vlan range is invalid format: %s", vr)
+		}
+
+		start, err := strconv.Atoi(vlans[0])
+		if err != nil {
+			return nil, fmt.Errorf("start of vlan range %s is not integer: %s", vr, err)
+		}
+
+		end, err := strconv.Atoi(vlans[1])
+		if err != nil {
+			return nil, fmt.Errorf("end of vlan range %s is not integer: %s", vr, err)
+		}
+
+		parsed = append(parsed, map[string]interface{}{
+			"start": start,
+			"end":   end,
+		})
+	}
+
+	sort.Slice(parsed, func(i, j int) bool {
+		return parsed[i]["start"].(int) < parsed[j]["start"].(int)
+	})
+
+	var result []map[string]interface{}
+	for _, c := range current {
+		c, ok := c.(map[string]interface{})
+		if !ok {
+			continue
+		}
+
+		for i, p := range parsed {
+			if p["start"] == c["start"] && p["end"] == c["end"] {
+				result = append(result, p)
+				parsed = append(parsed[:i], parsed[i+1:]...)
+				break
+			}
+		}
+	}
+
+	return append(result, parsed...), nil
+}
+
 func resourceEriPortV1Read(d *schema.ResourceData, meta interface{}) error {
 	config := meta.(*Config)
 	client, err := config.eriV1Client(GetRegion(d, config))
@@ -227,11 +374,19 @@ func resourceEriPortV1Read(d *schema.ResourceData, meta interface{}) error {
 
 	log.Printf("[DEBUG] Retrieved port %s: %+v", d.Id(), r)
 
+	d.Set("uri", client.ServiceURL("ports", d.Id()))
 	d.Set("name", r.Name)
 	d.Set("switch_name", r.SwitchName)
-	d.Set("vlan_ranges", r.VLANRanges)
+
+	vlanRanges, err := getVLANRangesForState(r.VLANRanges, d.Get("vlan_ranges").([]interface{}))
+	if err != nil {
+		return err
+	}
+	d.Set("vlan_ranges", vlanRanges)
+
 	d.Set("is_activated", r.IsActivated)
 	d.Set("tenant_id", r.TenantID)
+	d.Set("operation_status", r.OperationStatus)
 	d.Set("area", r.Area)
 	d.Set("location", r.Location)
 	d.Set("vlans", getVLANsForState(r))
@@ -249,23 +404,17 @@ func resourceEriPortV1Update(d *schema.ResourceData, meta interface{}) error {
 	if d.HasChange("is_activated") {
 		_, err := ports.Activate(client, d.Id()).Extract()
 		if err != nil {
-			return fmt.Errorf("Error activating FIC ERI port: %s", err)
-		}
-
-		log.Printf(
-			"[DEBUG] Waiting for port (%s) to become active", d.Id())
-
-		activateStateConf := &resource.StateChangeConf{
-			Pending:    []string{"Processing"},
-			Target:     []string{"Completed"},
-			Refresh:    PortV1StateRefreshFunc(client, d.Id()),
-			Timeout:    d.Timeout(schema.TimeoutCreate),
-			Delay:      10 * time.Second,
-			MinTimeout: 3 * time.Second,
+			return formatFICError(err, "Error activating FIC ERI port")
 		}
 
-		log.Printf("[DEBUG] Waiting for port (%s) to become active", d.Id())
-		_, err = activateStateConf.WaitForState()
+		_, err = config.waitForActive(statusWait{
+			ResourceType: "port",
+			ID:           d.Id(),
+			Refresh:      PortV1StateRefreshFunc(client, d.Id()),
+			Pending:      []string{"Processing"},
+			Target:       []string{"Completed"},
+			Timeout:      d.Timeout(schema.TimeoutUpdate),
+		})
 		if err != nil {
 			return fmt.Errorf("Error waiting for port (%s) to become active: %s", d.Id(), err)
 		}
@@ -285,18 +434,14 @@ func resourceEriPortV1Delete(d *schema.ResourceData, meta interface{}) error {
 		return CheckDeleted(d, err, "port")
 	}
 
-	log.Printf("[DEBUG] Waiting for port (%s) to delete", d.Id())
-
-	stateConf := &resource.StateChangeConf{
-		Pending:    []string{"Processing", "Completed"},
-		Target:     []string{"Deleted"},
-		Refresh:    PortV1StateRefreshFunc(client, d.Id()),
-		Timeout:    d.Timeout(schema.TimeoutDelete),
-		Delay:      10 * time.Second,
-		MinTimeout: 3 * time.Second,
-	}
-
-	_, err = stateConf.WaitForState()
+	_, err = waitForStatus(config.StopContext(), statusWait{
+		ResourceType: "port",
+		ID:           d.Id(),
+		Refresh:      PortV1StateRefreshFunc(client, d.Id()),
+		Pending:      []string{"Processing", "Completed"},
+		Target:       []string{"Deleted"},
+		Timeout:      d.Timeout(schema.TimeoutDelete),
+	})
 	if err != nil {
 		return fmt.Errorf(
 			"Error waiting for port (%s) to delete: %s",
@@ -1,15 +1,207 @@
 package fic
 
 import (
+	"encoding/json"
 	"fmt"
+	"net/http"
+	"reflect"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/hashicorp/terraform-plugin-sdk/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/terraform"
 
 	"github.com/nttcom/go-fic/fic/eri/v1/ports"
 )
 
+func TestGetVLANRangesForState(t *testing.T) {
+	apiRanges := []string{"1153-1168", "1137-1152", "1169-1184"}
+
+	vlanRanges, err := getVLANRangesForState(apiRanges, nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	expected := []map[string]interface{}{
+		{"start": 1137, "end": 1152},
+		{"start": 1153, "end": 1168},
+		{"start": 1169, "end": 1184},
+	}
+	if !reflect.DeepEqual(vlanRanges, expected) {
+		t.Fatalf("expected %v, got %v", expected, vlanRanges)
+	}
+
+	current := []interface{}{
+		map[string]interface{}{"start": 1169, "end": 1184},
+		map[string]interface{}{"start": 1137, "end": 1152},
+	}
+
+	vlanRanges, err = getVLANRangesForState(apiRanges, current)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	expected = []map[string]interface{}{
+		{"start": 1169, "end": 1184},
+		{"start": 1137, "end": 1152},
+		{"start": 1153, "end": 1168},
+	}
+	if !reflect.DeepEqual(vlanRanges, expected) {
+		t.Fatalf("expected %v, got %v", expected, vlanRanges)
+	}
+
+	if _, err := getVLANRangesForState([]string{"1137"}, nil); err == nil {
+		t.Fatal("expected an error for a malformed vlan range")
+	}
+}
+
+func TestGetVLANsForState(t *testing.T) {
+	p := &ports.Port{
+		VLANs: []ports.VLAN{
+			{VID: 1139, Status: "NOT_USED"},
+			{VID: 1137, Status: "USED"},
+			{VID: 1138, Status: "NOT_USED"},
+		},
+	}
+
+	expected := []map[string]interface{}{
+		{"vid": 1137, "status": "USED"},
+		{"vid": 1138, "status": "NOT_USED"},
+		{"vid": 1139, "status": "NOT_USED"},
+	}
+	if actual := getVLANsForState(p); !reflect.DeepEqual(actual, expected) {
+		t.Fatalf("expected %v, got %v", expected, actual)
+	}
+
+	if p.VLANs[0].VID != 1139 {
+		t.Fatal("expected the API response to be left untouched")
+	}
+}
+
+func TestResourceEriPortV1ReadURI(t *testing.T) {
+	config, closeServer := testMockConfig(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		fmt.Fprint(w, `{"port": {"id": "F010123456789", "name": "terraform_port_1", "operationStatus": "Completed"}}`)
+	}))
+	defer closeServer()
+
+	endpoint := config.EndpointOverrides["eri"]
+	config.EndpointOverrides["eri"] = endpoint + "/"
+
+	d := schema.TestResourceDataRaw(t, resourceEriPortV1().Schema, map[string]interface{}{})
+	d.SetId("F010123456789")
+
+	// The URI must not depend on a trailing slash of the endpoint, nor
+	// change between reads.
+	for i := 0; i < 2; i++ {
+		if err := resourceEriPortV1Read(d, config); err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+
+		expected := endpoint + "/v1/ports/F010123456789"
+		if v := d.Get("uri").(string); v != expected {
+			t.Fatalf("expected uri %s, got %s", expected, v)
+		}
+	}
+}
+
+func TestResourceEriPortV1CreateVLANRanges(t *testing.T) {
+	defer func(delay time.Duration) { statusWaitDelay = delay }(statusWaitDelay)
+	statusWaitDelay = 0
+
+	var body map[string]map[string]interface{}
+	config, closeServer := testMockConfig(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		if r.Method == http.MethodPost {
+			if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+				t.Errorf("unexpected error: %s", err)
+			}
+			w.WriteHeader(http.StatusAccepted)
+		}
+		fmt.Fprint(w, `{"port": {"id": "F010123456789", "name": "terraform_port_1", "operationStatus": "Completed",
+			"vlanRanges": ["1137-1152", "1169-1184"]}}`)
+	}))
+	defer closeServer()
+
+	d := schema.TestResourceDataRaw(t, resourceEriPortV1().Schema, map[string]interface{}{
+		"name":        "terraform_port_1",
+		"switch_name": "SwitchName",
+		"port_type":   "1G",
+		"vlan_ranges": []interface{}{
+			map[string]interface{}{"start": 1169, "end": 1184},
+			map[string]interface{}{"start": 1137, "end": 1152},
+		},
+	})
+
+	if err := resourceEriPortV1Create(d, config); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	expected := []interface{}{"1169-1184", "1137-1152"}
+	if actual := body["port"]["vlanRanges"]; !reflect.DeepEqual(actual, expected) {
+		t.Fatalf("expected vlanRanges %v, got %v", expected, actual)
+	}
+
+	if _, ok := body["port"]["numOfVlans"]; ok {
+		t.Fatalf("expected no numOfVlans along with vlanRanges, got %v", body["port"])
+	}
+}
+
+func TestResourceEriPortV1CustomizeDiff(t *testing.T) {
+	vlanRanges := func(bounds ...int) []interface{} {
+		var ranges []interface{}
+		for i := 0; i < len(bounds); i += 2 {
+			ranges = append(ranges, map[string]interface{}{"start": bounds[i], "end": bounds[i+1]})
+		}
+		return ranges
+	}
+
+	cases := []struct {
+		portType   string
+		vlanRanges []interface{}
+		errMatch   string
+	}{
+		{"1G", vlanRanges(1137, 1152), ""},
+		{"10G", vlanRanges(1, 512), ""},
+		{"10G", vlanRanges(1, 256, 1025, 1280), ""},
+		{"1G", vlanRanges(1, 528), "vlan_ranges hold 528 VLANs, but a 1G port holds at most 512"},
+		{"10G", vlanRanges(1, 256, 1025, 1296), "vlan_ranges hold 528 VLANs, but a 10G port holds at most 512"},
+		{testUnknownVariableValue, vlanRanges(1, 528), ""},
+		{"1G", vlanRanges(1152, 1137), "vlan_ranges.0 ends at VLAN 1137 before it starts at VLAN 1152"},
+		{"1G", vlanRanges(1153, 1168, 1137, 1153), "vlan_ranges.1 (1137-1153) and vlan_ranges.0 (1153-1168) overlap"},
+		{"10G", vlanRanges(1, 256, 129, 384), "vlan_ranges.0 (1-256) and vlan_ranges.1 (129-384) overlap"},
+		{testUnknownVariableValue, vlanRanges(1, 16, 16, 32), "vlan_ranges.0 (1-16) and vlan_ranges.1 (16-32) overlap"},
+		{"1G", []interface{}{
+			map[string]interface{}{"start": 1, "end": 512},
+			map[string]interface{}{"start": testUnknownVariableValue, "end": 1296},
+		}, ""},
+	}
+
+	r := resourceEriPortV1()
+	for i, tc := range cases {
+		raw := map[string]interface{}{
+			"name":        "terraform_port_1",
+			"switch_name": "SwitchName",
+			"port_type":   tc.portType,
+			"vlan_ranges": tc.vlanRanges,
+		}
+
+		_, err := r.Diff(nil, terraform.NewResourceConfigRaw(raw), nil)
+		if tc.errMatch == "" {
+			if err != nil {
+				t.Errorf("case %d: unexpected error: %s", i, err)
+			}
+			continue
+		}
+
+		if err == nil || !strings.Contains(err.Error(), tc.errMatch) {
+			t.Errorf("case %d: expected an error containing %q, got %v", i, tc.errMatch, err)
+		}
+	}
+}
+
 func TestAccEriPortV1Basic(t *testing.T) {
 	var port ports.Port
 
@@ -115,6 +307,11 @@ func TestAccEriPortV1CreateWithVLANRanges(t *testing.T) {
 						"fic_eri_port_v1.port_1", "is_activated", "true"),
 				),
 			},
+			resource.TestStep{
+				ResourceName:      "fic_eri_port_v1.port_1",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
 		},
 	})
 }
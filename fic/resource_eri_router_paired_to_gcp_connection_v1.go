@@ -32,6 +32,7 @@ func resourcePairedRouterToGCPConnection() *schema.Resource {
 				Type:         schema.TypeString,
 				Required:     true,
 				ForceNew:     true,
+				Sensitive:    true,
 				ValidateFunc: validation.StringMatch(regexp.MustCompile(`^[a-fA-F\d]{8}(-[a-fA-F\d]{4}){3}-[a-fA-F\d]{12}/[a-zA-Z\d-]*/[1,2]$`), "see https://cloud.google.com/network-connectivity/docs/interconnect/concepts/terminology?_ga=2.264742223.-1966628098.1560150466#pairingkey"),
 			},
 		},
@@ -43,6 +44,8 @@ func resourcePairedRouterToGCPConnection() *schema.Resource {
 		Update: resourcePairedRouterToGCPConnectionUpdate,
 		Delete: resourcePairedRouterToGCPConnectionDelete,
 
+		CustomizeDiff: resourcePairedRouterToGCPConnectionCustomizeDiff,
+
 		Timeouts: &schema.ResourceTimeout{
 			Create: schema.DefaultTimeout(10 * time.Minute),
 			Update: schema.DefaultTimeout(10 * time.Minute),
@@ -58,12 +61,18 @@ func resourcePairedRouterToGCPConnection() *schema.Resource {
 				Type:         schema.TypeString,
 				Required:     true,
 				ForceNew:     true,
-				ValidateFunc: validation.StringMatch(regexp.MustCompile(`^[\w&()-]{1,64}$`), "must be less than 64 characters in half-width alphanumeric characters and some symbols &()-_"),
+				ValidateFunc: ValidateFICName(),
 			},
 			"bandwidth": {
-				Type:         schema.TypeString,
-				Required:     true,
-				ValidateFunc: validation.StringInSlice([]string{"10M", "50M", "100M", "200M", "300M", "400M", "500M", "1G", "2G", "5G", "10G"}, false),
+				Type:             schema.TypeString,
+				Required:         true,
+				ValidateFunc:     validation.StringInSlice([]string{"10M", "50M", "100M", "200M", "300M", "400M", "500M", "1G", "2G", "5G", "10G"}, false),
+				DiffSuppressFunc: suppressEquivalentBandwidthDiff,
+			},
+			"force_recreate_on_downgrade": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  false,
 			},
 			"source": {
 				Type:     schema.TypeList,
@@ -155,10 +164,24 @@ func resourcePairedRouterToGCPConnection() *schema.Resource {
 				Type:     schema.TypeString,
 				Computed: true,
 			},
+			"recreate_on_error": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  false,
+			},
+			"adopt_existing": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  false,
+			},
 			"operation_status": {
 				Type:     schema.TypeString,
 				Computed: true,
 			},
+			"created_at": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
 			"primary_connected_network_address": {
 				Type:     schema.TypeString,
 				Computed: true,
@@ -167,6 +190,15 @@ func resourcePairedRouterToGCPConnection() *schema.Resource {
 				Type:     schema.TypeString,
 				Computed: true,
 			},
+			"raw_json": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+
+			"uri": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
 		},
 	}
 }
@@ -178,6 +210,10 @@ func resourcePairedRouterToGCPConnectionCreate(d *schema.ResourceData, meta inte
 		return fmt.Errorf("error creating FIC client: %w", err)
 	}
 
+	if adopted, err := adoptExistingConnection(d, meta, "router_to_gcp", resourcePairedRouterToGCPConnection()); err != nil || adopted {
+		return err
+	}
+
 	opts := &connections.CreateOpts{
 		Name:        d.Get("name").(string),
 		Source:      expandSource(d.Get("source").([]interface{})),
@@ -187,27 +223,26 @@ func resourcePairedRouterToGCPConnectionCreate(d *schema.ResourceData, meta inte
 
 	conn, err := connections.Create(client, opts).Extract()
 	if err != nil {
-		return fmt.Errorf("error creating FIC paired router to GCP connection: %w", err)
+		return formatFICError(err, "error creating FIC paired router to GCP connection")
 	}
 
 	d.SetId(conn.ID)
 
-	stateConf := &resource.StateChangeConf{
-		Pending:    []string{"Processing"},
-		Target:     []string{"Completed"},
-		Refresh:    routerToGCPConnectionRefresh(client, conn.ID),
-		Timeout:    d.Timeout(schema.TimeoutCreate),
-		Delay:      10 * time.Second,
-		MinTimeout: 5 * time.Second,
-	}
-
-	if _, err = stateConf.WaitForState(); err != nil {
+	if _, err = config.waitForActive(statusWait{
+		ResourceType: "connection",
+		ID:           conn.ID,
+		Refresh:      routerToGCPConnectionRefresh(client, conn.ID),
+		Pending:      []string{"Processing"},
+		Target:       []string{"Completed"},
+		Timeout:      d.Timeout(schema.TimeoutCreate),
+		MinTimeout:   5 * time.Second,
+	}); err != nil {
 		return fmt.Errorf("error waiting for connection (%s) to become ready: %w", conn.ID, err)
 	}
 
 	d.Set("operation_id", conn.OperationID)
 
-	return resourcePairedRouterToGCPConnectionRead(d, meta)
+	return readAfterCreate(d, meta, resourcePairedRouterToGCPConnectionRead)
 }
 
 func routerToGCPConnectionRefresh(c *fic.ServiceClient, id string) func() (interface{}, string, error) {
@@ -225,6 +260,22 @@ func routerToGCPConnectionRefresh(c *fic.ServiceClient, id string) func() (inter
 	}
 }
 
+// routerToGCPConnectionDeleteRefresh reports the "Deleted" state once the
+// connection is no longer found, so that Delete returns only after FIC has
+// actually removed it.
+func routerToGCPConnectionDeleteRefresh(c *fic.ServiceClient, id string) func() (interface{}, string, error) {
+	refresh := routerToGCPConnectionRefresh(c, id)
+
+	return func() (interface{}, string, error) {
+		conn, state, err := refresh()
+		if err == nil && conn == nil {
+			return id, "Deleted", nil
+		}
+
+		return conn, state, err
+	}
+}
+
 func resourcePairedRouterToGCPConnectionRead(d *schema.ResourceData, meta interface{}) error {
 	config := meta.(*Config)
 	client, err := config.eriV1Client(GetRegion(d, config))
@@ -237,6 +288,7 @@ func resourcePairedRouterToGCPConnectionRead(d *schema.ResourceData, meta interf
 		return CheckDeleted(d, err, "error getting FIC paired router to GCP connection")
 	}
 
+	d.Set("uri", client.ServiceURL("router-to-gcp-connections", d.Id()))
 	d.Set("name", conn.Name)
 	d.Set("bandwidth", conn.Bandwidth)
 	d.Set("source", flattenSource(conn.Source))
@@ -245,13 +297,23 @@ func resourcePairedRouterToGCPConnectionRead(d *schema.ResourceData, meta interf
 	d.Set("tenant_id", conn.TenantID)
 	d.Set("area", conn.Area)
 	d.Set("operation_status", conn.OperationStatus)
+	warnOnOperationError(d, conn.OperationStatus)
 	d.Set("primary_connected_network_address", conn.PrimaryConnectedNetworkAddress)
 	d.Set("secondary_connected_network_address", conn.SecondaryConnectedNetworkAddress)
 
+	if err := setRawJSON(d, config, conn); err != nil {
+		return err
+	}
+
 	return nil
 }
 
 func resourcePairedRouterToGCPConnectionUpdate(d *schema.ResourceData, meta interface{}) error {
+	// force_recreate_on_downgrade and recreate_on_error only affect the plan.
+	if !d.HasChange("source") && !d.HasChange("bandwidth") {
+		return resourcePairedRouterToGCPConnectionRead(d, meta)
+	}
+
 	config := meta.(*Config)
 	client, err := config.eriV1Client(GetRegion(d, config))
 	if err != nil {
@@ -270,19 +332,18 @@ func resourcePairedRouterToGCPConnectionUpdate(d *schema.ResourceData, meta inte
 
 	conn, err := connections.Update(client, d.Id(), opts).Extract()
 	if err != nil {
-		return fmt.Errorf("error updating FIC paired router to GCP connection: %w", err)
+		return formatFICError(err, "error updating FIC paired router to GCP connection")
 	}
 
-	stateConf := &resource.StateChangeConf{
-		Pending:    []string{"Processing"},
-		Target:     []string{"Completed"},
-		Refresh:    routerToGCPConnectionRefresh(client, conn.ID),
-		Timeout:    d.Timeout(schema.TimeoutUpdate),
-		Delay:      10 * time.Second,
-		MinTimeout: 5 * time.Second,
-	}
-
-	if _, err = stateConf.WaitForState(); err != nil {
+	if _, err = waitForStatus(config.StopContext(), statusWait{
+		ResourceType: "connection",
+		ID:           conn.ID,
+		Refresh:      routerToGCPConnectionRefresh(client, conn.ID),
+		Pending:      []string{"Processing"},
+		Target:       []string{"Completed"},
+		Timeout:      d.Timeout(schema.TimeoutUpdate),
+		MinTimeout:   5 * time.Second,
+	}); err != nil {
 		return fmt.Errorf("error waiting for connection (%s) to become ready: %w", conn.ID, err)
 	}
 
@@ -291,6 +352,38 @@ func resourcePairedRouterToGCPConnectionUpdate(d *schema.ResourceData, meta inte
 	return resourcePairedRouterToGCPConnectionRead(d, meta)
 }
 
+// resourcePairedRouterToGCPConnectionCustomizeDiff plans a bandwidth
+// decrease as a replacement of the connection when
+// force_recreate_on_downgrade is set. Otherwise the new bandwidth is applied
+// in place and the API rejects transitions it does not support. Bandwidths
+// are ordered by their value, so "1G" is greater than "500M". A connection
+// in the Error state is handled by customizeDiffRecreateOnError first.
+func resourcePairedRouterToGCPConnectionCustomizeDiff(d *schema.ResourceDiff, meta interface{}) error {
+	if err := customizeDiffRecreateOnError(d, meta); err != nil {
+		return err
+	}
+
+	if !d.Get("force_recreate_on_downgrade").(bool) {
+		return nil
+	}
+
+	if d.Id() == "" || !d.HasChange("bandwidth") || !d.NewValueKnown("bandwidth") {
+		return nil
+	}
+
+	o, n := d.GetChange("bandwidth")
+	oldBandwidth, ok := parseBandwidth(o.(string))
+	if !ok {
+		return nil
+	}
+	newBandwidth, ok := parseBandwidth(n.(string))
+	if !ok || newBandwidth >= oldBandwidth {
+		return nil
+	}
+
+	return d.ForceNew("bandwidth")
+}
+
 func resourcePairedRouterToGCPConnectionDelete(d *schema.ResourceData, meta interface{}) error {
 	config := meta.(*Config)
 	client, err := config.eriV1Client(GetRegion(d, config))
@@ -298,26 +391,33 @@ func resourcePairedRouterToGCPConnectionDelete(d *schema.ResourceData, meta inte
 		return fmt.Errorf("error creating FIC client: %w", err)
 	}
 
-	err = resource.Retry(d.Timeout(schema.TimeoutDelete), func() *resource.RetryError {
+	err = retryWithBackoff(config.StopContext(), d.Timeout(schema.TimeoutDelete), config.Backoff, func() *resource.RetryError {
 		if err := connections.Delete(client, d.Id()).ExtractErr(); err != nil {
 			var e404 fic.ErrDefault404
 			if errors.As(err, &e404) {
 				return nil
 			}
 
-			var e409 fic.ErrDefault409
-			if errors.As(err, &e409) {
-				return resource.RetryableError(err)
-			}
-
-			return resource.NonRetryableError(err)
+			return checkForRetryableError(err)
 		}
 
 		return nil
 	})
 
 	if err != nil {
-		return fmt.Errorf("error deleting FIC paired router to GCP connection: %w", err)
+		return formatFICError(err, "error deleting FIC paired router to GCP connection")
+	}
+
+	if _, err = waitForStatus(config.StopContext(), statusWait{
+		ResourceType: "connection",
+		ID:           d.Id(),
+		Refresh:      routerToGCPConnectionDeleteRefresh(client, d.Id()),
+		Pending:      []string{"Processing", "Completed"},
+		Target:       []string{"Deleted"},
+		Timeout:      d.Timeout(schema.TimeoutDelete),
+		MinTimeout:   5 * time.Second,
+	}); err != nil {
+		return fmt.Errorf("error waiting for connection (%s) to be deleted: %w", d.Id(), err)
 	}
 
 	d.SetId("")
@@ -1,11 +1,17 @@
 package fic
 
 import (
+	"context"
 	"errors"
 	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/hashicorp/terraform-plugin-sdk/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
 
 	"github.com/nttcom/go-fic"
 
@@ -209,3 +215,167 @@ resource "fic_eri_router_paired_to_gcp_connection_v1" "test" {
 }
 `, rName, bandwidth, routeFilterIn, routeFilterOut, primaryMEDOut)
 }
+
+func TestRouterToGCPConnectionDeleteRefresh(t *testing.T) {
+	gets := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gets++
+		if gets > 2 {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		fmt.Fprint(w, `{"connection": {"id": "F030123456789", "operationStatus": "Processing"}}`)
+	}))
+	defer server.Close()
+
+	client := &fic.ServiceClient{
+		ProviderClient: &fic.ProviderClient{},
+		Endpoint:       server.URL + "/v1/",
+	}
+
+	stateConf := &resource.StateChangeConf{
+		Pending:    []string{"Processing", "Completed"},
+		Target:     []string{"Deleted"},
+		Refresh:    routerToGCPConnectionDeleteRefresh(client, "F030123456789"),
+		Timeout:    time.Minute,
+		MinTimeout: 10 * time.Millisecond,
+	}
+
+	if _, err := waitForState(context.Background(), stateConf); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	if gets != 3 {
+		t.Fatalf("expected the poll to stop at the first 404, got %d requests", gets)
+	}
+}
+
+func TestPairedRouterToGCPConnectionCustomizeDiff(t *testing.T) {
+	cases := []struct {
+		oldBandwidth string
+		newBandwidth string
+		forceNew     bool
+		recreate     bool
+	}{
+		{oldBandwidth: "100M", newBandwidth: "1G"},
+		{oldBandwidth: "500M", newBandwidth: "1G"},
+		{oldBandwidth: "1G", newBandwidth: "500M"},
+		{oldBandwidth: "10G", newBandwidth: "10M"},
+		{oldBandwidth: "1G", newBandwidth: "500M", forceNew: true, recreate: true},
+		{oldBandwidth: "100M", newBandwidth: "1G", forceNew: true},
+		{oldBandwidth: "1000M", newBandwidth: "1G"},
+	}
+
+	r := resourcePairedRouterToGCPConnection()
+	for i, tc := range cases {
+		state := schema.TestResourceDataRaw(t, r.Schema, testPairedRouterToGCPConnectionRawConfig(tc.oldBandwidth, false))
+		state.SetId("F030123456789")
+
+		config := testPairedRouterToGCPConnectionRawConfig(tc.newBandwidth, tc.forceNew)
+		diff, err := r.Diff(state.State(), terraform.NewResourceConfigRaw(config), nil)
+		if err != nil {
+			t.Errorf("case %d: unexpected error: %s", i, err)
+			continue
+		}
+
+		if recreate := diff != nil && diff.RequiresNew(); recreate != tc.recreate {
+			t.Errorf("case %d: expected replacement %t, got %t", i, tc.recreate, recreate)
+		}
+	}
+}
+
+func TestPairedRouterToGCPConnectionCustomizeDiffDecreaseInPlace(t *testing.T) {
+	r := resourcePairedRouterToGCPConnection()
+	state := schema.TestResourceDataRaw(t, r.Schema, testPairedRouterToGCPConnectionRawConfig("1G", false))
+	state.SetId("F030123456789")
+
+	config := testPairedRouterToGCPConnectionRawConfig("500M", false)
+	diff, err := r.Diff(state.State(), terraform.NewResourceConfigRaw(config), nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	attr, ok := diff.Attributes["bandwidth"]
+	if !ok || attr.Old != "1G" || attr.New != "500M" {
+		t.Fatalf("expected bandwidth to change from 1G to 500M, got %+v", attr)
+	}
+
+	if attr.RequiresNew || diff.RequiresNew() {
+		t.Fatal("expected the decrease to be an in-place update")
+	}
+}
+
+func TestPairedRouterToGCPConnectionCreateError(t *testing.T) {
+	config, closeServer := testMockConfig(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		if r.URL.Path == "/v1/router-to-gcp-connections" && r.Method == http.MethodGet {
+			fmt.Fprint(w, `{"connections": []}`)
+			return
+		}
+
+		w.WriteHeader(http.StatusBadRequest)
+		fmt.Fprint(w, `{"operationId": "8d49e2ab41a54598aec02c0f198ab0e3", "errors": [
+			{"field": "name", "code": "ERI-01-0008", "message": "name is too long"},
+			{"field": "bandwidth", "code": "ERI-01-0012", "message": "bandwidth is not supported"}
+		]}`)
+	}))
+	defer closeServer()
+
+	r := resourcePairedRouterToGCPConnection()
+	d := schema.TestResourceDataRaw(t, r.Schema, testPairedRouterToGCPConnectionRawConfig("100M", false))
+
+	err := resourcePairedRouterToGCPConnectionCreate(d, config)
+	if err == nil {
+		t.Fatal("expected an error")
+	}
+
+	for _, expected := range []string{
+		"error creating FIC paired router to GCP connection (operation ID: 8d49e2ab41a54598aec02c0f198ab0e3): ",
+		"\n  - name (ERI-01-0008) name is too long",
+		"\n  - bandwidth (ERI-01-0012) bandwidth is not supported",
+	} {
+		if !strings.Contains(err.Error(), expected) {
+			t.Errorf("expected %q in %q", expected, err)
+		}
+	}
+}
+
+func testPairedRouterToGCPConnectionRawConfig(bandwidth string, forceRecreateOnDowngrade bool) map[string]interface{} {
+	return map[string]interface{}{
+		"name":                        "terraform_connection_1",
+		"bandwidth":                   bandwidth,
+		"force_recreate_on_downgrade": forceRecreateOnDowngrade,
+		"source": []interface{}{
+			map[string]interface{}{
+				"router_id":  "F020123456789",
+				"group_name": "group_1",
+				"route_filter": []interface{}{
+					map[string]interface{}{
+						"in":  "fullRoute",
+						"out": "fullRouteWithDefaultRoute",
+					},
+				},
+				"primary_med_out":   10,
+				"secondary_med_out": 20,
+			},
+		},
+		"destination": []interface{}{
+			map[string]interface{}{
+				"primary": []interface{}{
+					map[string]interface{}{
+						"interconnect": "Equinix-TY2-2",
+						"pairing_key":  "01234567-89ab-cdef-0123-456789abcdef/asia-northeast1/1",
+					},
+				},
+				"secondary": []interface{}{
+					map[string]interface{}{
+						"interconnect": "@Tokyo-CC2-2",
+						"pairing_key":  "01234567-89ab-cdef-0123-456789abcdef/asia-northeast1/2",
+					},
+				},
+			},
+		},
+	}
+}
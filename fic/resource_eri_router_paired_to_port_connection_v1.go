@@ -21,19 +21,23 @@ func resourceEriRouterPairedToPortConnectionV1() *schema.Resource {
 		Update: resourceEriRouterPairedToPortConnectionV1Update,
 		Delete: resourceEriRouterPairedToPortConnectionV1Delete,
 		Importer: &schema.ResourceImporter{
-			State: schema.ImportStatePassthrough,
+			State: importRouterToPortConnection,
 		},
 
+		CustomizeDiff: resourceEriRouterPairedToPortConnectionV1CustomizeDiff,
+
 		Timeouts: &schema.ResourceTimeout{
 			Create: schema.DefaultTimeout(10 * time.Minute),
+			Update: schema.DefaultTimeout(10 * time.Minute),
 			Delete: schema.DefaultTimeout(10 * time.Minute),
 		},
 
 		Schema: map[string]*schema.Schema{
 			"name": &schema.Schema{
-				Type:     schema.TypeString,
-				Required: true,
-				ForceNew: true,
+				Type:         schema.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				ValidateFunc: ValidateFICName(),
 			},
 
 			"source_router_id": &schema.Schema{
@@ -81,14 +85,16 @@ func resourceEriRouterPairedToPortConnectionV1() *schema.Resource {
 							Required: true,
 						},
 						"as_path_prepend_in": &schema.Schema{
-							Type:     schema.TypeString,
-							Optional: true,
+							Type:             schema.TypeString,
+							Optional:         true,
+							DiffSuppressFunc: suppressASPathPrependOffDiff,
 							ValidateFunc: validation.StringInSlice(
 								[]string{"OFF", "1", "2", "3", "4", "5"}, false),
 						},
 						"as_path_prepend_out": &schema.Schema{
-							Type:     schema.TypeString,
-							Optional: true,
+							Type:             schema.TypeString,
+							Optional:         true,
+							DiffSuppressFunc: suppressASPathPrependOffDiff,
 							ValidateFunc: validation.StringInSlice(
 								[]string{"OFF", "1", "2", "3", "4", "5"}, false),
 						},
@@ -117,8 +123,10 @@ func resourceEriRouterPairedToPortConnectionV1() *schema.Resource {
 							Required: true,
 						},
 						"asn": &schema.Schema{
-							Type:     schema.TypeString,
-							Required: true,
+							Type:         schema.TypeString,
+							Required:     true,
+							ForceNew:     true,
+							ValidateFunc: ValidateASN(),
 						},
 					},
 				},
@@ -132,6 +140,7 @@ func resourceEriRouterPairedToPortConnectionV1() *schema.Resource {
 					"10M", "20M", "30M", "40M", "50M", "100M", "200M", "300M", "400M", "500M",
 					"1G", "2G", "3G", "4G", "5G", "10G",
 				}, false),
+				DiffSuppressFunc: suppressEquivalentBandwidthDiff,
 			},
 
 			"redundant": &schema.Schema{
@@ -144,10 +153,42 @@ func resourceEriRouterPairedToPortConnectionV1() *schema.Resource {
 				Computed: true,
 			},
 
+			"recreate_on_error": &schema.Schema{
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  false,
+			},
+
+			"adopt_existing": &schema.Schema{
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  false,
+			},
+
+			"operation_status": &schema.Schema{
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+
+			"created_at": &schema.Schema{
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+
 			"area": &schema.Schema{
 				Type:     schema.TypeString,
 				Computed: true,
 			},
+
+			"raw_json": &schema.Schema{
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+
+			"uri": &schema.Schema{
+				Type:     schema.TypeString,
+				Computed: true,
+			},
 		},
 	}
 }
@@ -282,6 +323,20 @@ func getDestinationOfRouterPairedToPortConnection(d *schema.ResourceData) connec
 	return destination
 }
 
+// resourceEriRouterPairedToPortConnectionV1CustomizeDiff rejects primary and
+// secondary destinations on the same VLAN of the same port, which FIC only
+// rejects once the connection is being created. A connection in the Error
+// state is handled by customizeDiffRecreateOnError first.
+func resourceEriRouterPairedToPortConnectionV1CustomizeDiff(d *schema.ResourceDiff, meta interface{}) error {
+	warnReplacedASNs(d, "destination_information.0.asn", "destination_information.1.asn")
+
+	if err := customizeDiffRecreateOnError(d, meta); err != nil {
+		return err
+	}
+
+	return checkDuplicatePortVLANs(d, "destination_information")
+}
+
 func resourceEriRouterPairedToPortConnectionV1Create(d *schema.ResourceData, meta interface{}) error {
 	config := meta.(*Config)
 	client, err := config.eriV1Client(GetRegion(d, config))
@@ -289,6 +344,10 @@ func resourceEriRouterPairedToPortConnectionV1Create(d *schema.ResourceData, met
 		return fmt.Errorf("Error creating FIC ERI client: %s", err)
 	}
 
+	if adopted, err := adoptExistingConnection(d, meta, "router_to_port", resourceEriRouterPairedToPortConnectionV1()); err != nil || adopted {
+		return err
+	}
+
 	createOpts := &connections.CreateOpts{
 		Name:        d.Get("name").(string),
 		Source:      getSourceOfRouterPairedToPortConnection(d),
@@ -299,44 +358,39 @@ func resourceEriRouterPairedToPortConnectionV1Create(d *schema.ResourceData, met
 	log.Printf("[DEBUG] Create Options: %#v", createOpts)
 	r, err := connections.Create(client, createOpts).Extract()
 	if err != nil {
-		return fmt.Errorf("Error creating FIC ERI connection(router to port): %s", err)
+		return formatFICError(err, "Error creating FIC ERI connection(router to port)")
 	}
 
 	d.SetId(r.ID)
 
 	log.Printf("[INFO] Connection ID: %s", r.ID)
 
-	log.Printf(
-		"[DEBUG] Waiting for connection (%s) to become available", r.ID)
-
-	stateConf := &resource.StateChangeConf{
-		Pending:    []string{"Processing"},
-		Target:     []string{"Completed"},
-		Refresh:    RouterToPortConnectionV1StateRefreshFunc(client, r.ID),
-		Timeout:    d.Timeout(schema.TimeoutCreate),
-		Delay:      10 * time.Second,
-		MinTimeout: 3 * time.Second,
-	}
-
-	_, err = stateConf.WaitForState()
+	_, err = config.waitForActive(statusWait{
+		ResourceType: "connection",
+		ID:           r.ID,
+		Refresh:      RouterToPortConnectionV1StateRefreshFunc(client, r.ID),
+		Pending:      []string{"Processing"},
+		Target:       []string{"Completed"},
+		Timeout:      d.Timeout(schema.TimeoutCreate),
+	})
 	if err != nil {
 		return fmt.Errorf(
 			"Error waiting for connection (%s) to become ready: %s", r.ID, err)
 	}
 
-	return resourceEriRouterPairedToPortConnectionV1Read(d, meta)
+	return readAfterCreate(d, meta, resourceEriRouterPairedToPortConnectionV1Read)
 }
 
 func getSourceInformationOfRouterPairedToPortConnectionForState(r *connections.Connection) []map[string]interface{} {
 	primary := map[string]interface{}{
 		"ip_address":          r.Source.Primary.IPAddress,
-		"as_path_prepend_in":  r.Source.Primary.ASPathPrepend.In,
-		"as_path_prepend_out": r.Source.Primary.ASPathPrepend.Out,
+		"as_path_prepend_in":  flattenASPathPrepend(r.Source.Primary.ASPathPrepend.In),
+		"as_path_prepend_out": flattenASPathPrepend(r.Source.Primary.ASPathPrepend.Out),
 	}
 	secondary := map[string]interface{}{
 		"ip_address":          r.Source.Secondary.IPAddress,
-		"as_path_prepend_in":  r.Source.Secondary.ASPathPrepend.In,
-		"as_path_prepend_out": r.Source.Secondary.ASPathPrepend.Out,
+		"as_path_prepend_in":  flattenASPathPrepend(r.Source.Secondary.ASPathPrepend.In),
+		"as_path_prepend_out": flattenASPathPrepend(r.Source.Secondary.ASPathPrepend.Out),
 	}
 	return []map[string]interface{}{
 		primary,
@@ -377,6 +431,7 @@ func resourceEriRouterPairedToPortConnectionV1Read(d *schema.ResourceData, meta
 
 	log.Printf("[DEBUG] Retrieved connection %s: %+v", d.Id(), r)
 
+	d.Set("uri", client.ServiceURL("router-to-port-connections", d.Id()))
 	d.Set("name", r.Name)
 
 	d.Set("source_router_id", r.Source.RouterID)
@@ -391,8 +446,14 @@ func resourceEriRouterPairedToPortConnectionV1Read(d *schema.ResourceData, meta
 	d.Set("bandwidth", r.Bandwidth)
 	d.Set("redundant", r.Redundant)
 	d.Set("tenant_id", r.TenantID)
+	d.Set("operation_status", r.OperationStatus)
+	warnOnOperationError(d, r.OperationStatus)
 	d.Set("area", r.Area)
 
+	if err := setRawJSON(d, config, r); err != nil {
+		return err
+	}
+
 	return nil
 }
 
@@ -409,23 +470,17 @@ func resourceEriRouterPairedToPortConnectionV1Update(d *schema.ResourceData, met
 		}
 		_, err := connections.Update(client, d.Id(), updateOpts).Extract()
 		if err != nil {
-			return fmt.Errorf("Error activating FIC ERI connection: %s", err)
+			return formatFICError(err, "Error activating FIC ERI connection")
 		}
 
-		log.Printf(
-			"[DEBUG] Waiting for connection (%s) to become complete", d.Id())
-
-		stateConf := &resource.StateChangeConf{
-			Pending:    []string{"Processing"},
-			Target:     []string{"Completed"},
-			Refresh:    RouterToPortConnectionV1StateRefreshFunc(client, d.Id()),
-			Timeout:    d.Timeout(schema.TimeoutCreate),
-			Delay:      10 * time.Second,
-			MinTimeout: 3 * time.Second,
-		}
-
-		log.Printf("[DEBUG] Waiting for connection (%s) to become complete", d.Id())
-		_, err = stateConf.WaitForState()
+		_, err = waitForStatus(config.StopContext(), statusWait{
+			ResourceType: "connection",
+			ID:           d.Id(),
+			Refresh:      RouterToPortConnectionV1StateRefreshFunc(client, d.Id()),
+			Pending:      []string{"Processing"},
+			Target:       []string{"Completed"},
+			Timeout:      d.Timeout(schema.TimeoutUpdate),
+		})
 		if err != nil {
 			return fmt.Errorf("Error waiting for connection (%s) to become complete: %s", d.Id(), err)
 		}
@@ -445,18 +500,14 @@ func resourceEriRouterPairedToPortConnectionV1Delete(d *schema.ResourceData, met
 		return CheckDeleted(d, err, "connection")
 	}
 
-	log.Printf("[DEBUG] Waiting for connection (%s) to delete", d.Id())
-
-	stateConf := &resource.StateChangeConf{
-		Pending:    []string{"Processing", "Completed"},
-		Target:     []string{"Deleted"},
-		Refresh:    RouterToPortConnectionV1StateRefreshFunc(client, d.Id()),
-		Timeout:    d.Timeout(schema.TimeoutDelete),
-		Delay:      10 * time.Second,
-		MinTimeout: 3 * time.Second,
-	}
-
-	_, err = stateConf.WaitForState()
+	_, err = waitForStatus(config.StopContext(), statusWait{
+		ResourceType: "connection",
+		ID:           d.Id(),
+		Refresh:      RouterToPortConnectionV1StateRefreshFunc(client, d.Id()),
+		Pending:      []string{"Processing", "Completed"},
+		Target:       []string{"Deleted"},
+		Timeout:      d.Timeout(schema.TimeoutDelete),
+	})
 	if err != nil {
 		return fmt.Errorf(
 			"Error waiting for connection (%s) to delete: %s",
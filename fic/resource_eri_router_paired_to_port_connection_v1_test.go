@@ -1,15 +1,282 @@
 package fic
 
 import (
+	"bytes"
 	"fmt"
+	"log"
+	"net/http"
+	"os"
+	"strings"
 	"testing"
 
 	"github.com/hashicorp/terraform-plugin-sdk/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/terraform"
 
 	connections "github.com/nttcom/go-fic/fic/eri/v1/router_paired_to_port_connections"
 )
 
+func TestResourceEriRouterPairedToPortConnectionV1CustomizeDiff(t *testing.T) {
+	cases := []struct {
+		primaryPortID   string
+		primaryVLAN     int
+		secondaryPortID string
+		secondaryVLAN   int
+		errMatch        string
+	}{
+		{"F010123456789", 1137, "F010123456789", 1137, "destination_information.0 and destination_information.1 both use VLAN 1137 of port F010123456789"},
+		{"F010123456789", 1137, "F010123456789", 1138, ""},
+		{"F010123456789", 1137, "F019876543210", 1137, ""},
+		{"F010123456789", 1137, testUnknownVariableValue, 1137, ""},
+	}
+
+	r := resourceEriRouterPairedToPortConnectionV1()
+	for i, tc := range cases {
+		raw := testRouterPairedToPortConnectionV1RawConfig(tc.primaryPortID, tc.primaryVLAN, tc.secondaryPortID, tc.secondaryVLAN)
+
+		_, err := r.Diff(nil, terraform.NewResourceConfigRaw(raw), nil)
+		if tc.errMatch == "" {
+			if err != nil {
+				t.Errorf("case %d: unexpected error: %s", i, err)
+			}
+			continue
+		}
+
+		if err == nil || !strings.Contains(err.Error(), tc.errMatch) {
+			t.Errorf("case %d: expected an error containing %q, got %v", i, tc.errMatch, err)
+		}
+	}
+}
+
+func testRouterPairedToPortConnectionV1RawConfig(primaryPortID string, primaryVLAN int, secondaryPortID string, secondaryVLAN int) map[string]interface{} {
+	return map[string]interface{}{
+		"name":                    "terraform_connection_1",
+		"source_router_id":        "F020123456789",
+		"source_group_name":       "group_1",
+		"source_route_filter_in":  "fullRoute",
+		"source_route_filter_out": "fullRoute",
+		"source_information": []interface{}{
+			map[string]interface{}{"ip_address": "10.0.0.1/30"},
+			map[string]interface{}{"ip_address": "10.0.0.5/30"},
+		},
+		"destination_information": []interface{}{
+			map[string]interface{}{"port_id": primaryPortID, "vlan": primaryVLAN, "ip_address": "10.0.0.2/30", "asn": "65000"},
+			map[string]interface{}{"port_id": secondaryPortID, "vlan": secondaryVLAN, "ip_address": "10.0.0.6/30", "asn": "65000"},
+		},
+		"bandwidth": "10M",
+	}
+}
+
+func TestResourceEriRouterPairedToPortConnectionV1DiffASN(t *testing.T) {
+	var buf bytes.Buffer
+	log.SetOutput(&buf)
+	defer log.SetOutput(os.Stderr)
+
+	state := &terraform.InstanceState{
+		ID: "F030123456789",
+		Attributes: map[string]string{
+			"id":                                   "F030123456789",
+			"name":                                 "terraform_connection_1",
+			"source_router_id":                     "F020123456789",
+			"source_group_name":                    "group_1",
+			"source_route_filter_in":               "fullRoute",
+			"source_route_filter_out":              "fullRoute",
+			"source_information.#":                 "2",
+			"source_information.0.ip_address":      "10.0.0.1/30",
+			"source_information.1.ip_address":      "10.0.0.5/30",
+			"destination_information.#":            "2",
+			"destination_information.0.port_id":    "F010123456789",
+			"destination_information.0.vlan":       "1137",
+			"destination_information.0.ip_address": "10.0.0.2/30",
+			"destination_information.0.asn":        "65000",
+			"destination_information.1.port_id":    "F019876543210",
+			"destination_information.1.vlan":       "1137",
+			"destination_information.1.ip_address": "10.0.0.6/30",
+			"destination_information.1.asn":        "65000",
+			"bandwidth":                            "10M",
+			"operation_status":                     "Completed",
+		},
+	}
+
+	raw := testRouterPairedToPortConnectionV1RawConfig("F010123456789", 1137, "F019876543210", 1137)
+	raw["destination_information"].([]interface{})[1].(map[string]interface{})["asn"] = "65001"
+
+	r := resourceEriRouterPairedToPortConnectionV1()
+	diff, err := r.Diff(state, terraform.NewResourceConfigRaw(raw), nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	if diff == nil || !diff.RequiresNew() {
+		t.Fatalf("expected the connection to be replaced, got %#v", diff)
+	}
+
+	if a := diff.Attributes["destination_information.1.asn"]; a == nil || !a.RequiresNew {
+		t.Fatalf("expected destination_information.1.asn to force the replacement, got %#v", a)
+	}
+
+	expected := "[WARN] Connection F030123456789 will be replaced: destination_information.1.asn changes from 65000 to 65001"
+	if !strings.Contains(buf.String(), expected) {
+		t.Fatalf("expected a warning containing %q, got %q", expected, buf.String())
+	}
+	if strings.Contains(buf.String(), "destination_information.0.asn") {
+		t.Fatalf("expected no warning for the unchanged primary ASN, got %q", buf.String())
+	}
+}
+
+func TestResourceEriRouterPairedToPortConnectionV1Import(t *testing.T) {
+	config, closeServer := testMockConfig(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		fmt.Fprint(w, `{"connections": [
+			{"id": "F030123456781", "source": {"routerId": "F020123456789"},
+			 "destination": {"primary": {"vlan": 101}, "secondary": {"vlan": 102}}},
+			{"id": "F030123456782", "source": {"routerId": "F029876543210"},
+			 "destination": {"primary": {"vlan": 102}, "secondary": {"vlan": 103}}}
+		]}`)
+	}))
+	defer closeServer()
+
+	cases := []struct {
+		importID string
+		id       string
+		errMatch string
+	}{
+		{importID: "F030123456789", id: "F030123456789"},
+		{importID: "F020123456789/102", id: "F030123456781"},
+		{importID: "F029876543210/102", id: "F030123456782"},
+		{importID: "F020123456789/103", errMatch: "no results"},
+		{importID: "F020123456789/vlan", errMatch: "invalid VLAN"},
+		{importID: "F020123456789/102/1", errMatch: "invalid ID"},
+	}
+
+	r := resourceEriRouterPairedToPortConnectionV1()
+	for _, tc := range cases {
+		d := r.Data(nil)
+		d.SetId(tc.importID)
+
+		ds, err := r.Importer.State(d, config)
+		if tc.errMatch != "" {
+			if err == nil || !strings.Contains(err.Error(), tc.errMatch) {
+				t.Errorf("%s: expected an error containing %q, got %v", tc.importID, tc.errMatch, err)
+			}
+			continue
+		}
+
+		if err != nil {
+			t.Errorf("%s: unexpected error: %s", tc.importID, err)
+			continue
+		}
+
+		if len(ds) != 1 || ds[0].Id() != tc.id {
+			t.Errorf("%s: expected ID %s, got %v", tc.importID, tc.id, ds)
+		}
+	}
+}
+
+func TestResourceEriRouterPairedToPortConnectionV1CreateAdoptExisting(t *testing.T) {
+	cases := []struct {
+		secondaryVLAN int
+		errMatch      string
+	}{
+		{1137, ""},
+		{1138, "destination_information.1.vlan is 1138, configured 1137"},
+	}
+
+	for _, tc := range cases {
+		posts := 0
+		config, closeServer := testMockConfig(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Set("Content-Type", "application/json")
+			switch {
+			case r.Method == http.MethodPost:
+				posts++
+				w.WriteHeader(http.StatusAccepted)
+				fmt.Fprint(w, `{"connection": {"id": "F030000000002", "operationStatus": "Processing"}}`)
+			case r.URL.Path == "/v1/router-to-port-connections":
+				fmt.Fprint(w, `{"connections": [{"id": "F030123456789", "name": "terraform_connection_1"}]}`)
+			default:
+				fmt.Fprintf(w, `{"connection": {"id": "F030123456789", "name": "terraform_connection_1", "bandwidth": "10M", "operationStatus": "Completed",
+					"source": {"routerId": "F020123456789", "groupName": "group_1", "routeFilter": {"in": "fullRoute", "out": "fullRoute"},
+						"primary": {"ipAddress": "10.0.0.1/30"}, "secondary": {"ipAddress": "10.0.0.5/30"}},
+					"destination": {
+						"primary": {"portId": "F010123456789", "vlan": 1137, "ipAddress": "10.0.0.2/30", "asn": "65000"},
+						"secondary": {"portId": "F019876543210", "vlan": %d, "ipAddress": "10.0.0.6/30", "asn": "65000"}}}}`, tc.secondaryVLAN)
+			}
+		}))
+
+		raw := testRouterPairedToPortConnectionV1RawConfig("F010123456789", 1137, "F019876543210", 1137)
+		raw["adopt_existing"] = true
+		r := resourceEriRouterPairedToPortConnectionV1()
+		d := schema.TestResourceDataRaw(t, r.Schema, raw)
+
+		err := resourceEriRouterPairedToPortConnectionV1Create(d, config)
+		closeServer()
+
+		if posts != 0 {
+			t.Errorf("VLAN %d: expected no connection to be created, got %d POST requests", tc.secondaryVLAN, posts)
+		}
+
+		if tc.errMatch == "" {
+			if err != nil {
+				t.Fatalf("VLAN %d: unexpected error: %s", tc.secondaryVLAN, err)
+			}
+			if d.Id() != "F030123456789" {
+				t.Fatalf("VLAN %d: expected the connection to be adopted, got ID %q", tc.secondaryVLAN, d.Id())
+			}
+			continue
+		}
+
+		if err == nil || !strings.Contains(err.Error(), tc.errMatch) {
+			t.Fatalf("VLAN %d: expected an error containing %q, got %v", tc.secondaryVLAN, tc.errMatch, err)
+		}
+		if d.Id() != "" {
+			t.Fatalf("VLAN %d: expected no ID on conflict, got %q", tc.secondaryVLAN, d.Id())
+		}
+	}
+}
+
+func TestResourceEriRouterPairedToPortConnectionV1RecreateOnError(t *testing.T) {
+	config, closeServer := testMockConfig(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		fmt.Fprint(w, `{"connection": {"id": "F030123456789", "name": "terraform_connection_1", "bandwidth": "10M", "operationStatus": "Error",
+			"source": {"routerId": "F020123456789", "groupName": "group_1", "routeFilter": {"in": "fullRoute", "out": "fullRoute"},
+				"primary": {"ipAddress": "10.0.0.1/30"}, "secondary": {"ipAddress": "10.0.0.5/30"}},
+			"destination": {
+				"primary": {"portId": "F010123456789", "vlan": 1137, "ipAddress": "10.0.0.2/30", "asn": "65000"},
+				"secondary": {"portId": "F019876543210", "vlan": 1137, "ipAddress": "10.0.0.6/30", "asn": "65000"}}}}`)
+	}))
+	defer closeServer()
+
+	var buf bytes.Buffer
+	log.SetOutput(&buf)
+	defer log.SetOutput(os.Stderr)
+
+	r := resourceEriRouterPairedToPortConnectionV1()
+	for _, recreate := range []bool{false, true} {
+		raw := testRouterPairedToPortConnectionV1RawConfig("F010123456789", 1137, "F019876543210", 1137)
+		raw["recreate_on_error"] = recreate
+
+		d := schema.TestResourceDataRaw(t, r.Schema, raw)
+		d.SetId("F030123456789")
+		buf.Reset()
+		if err := resourceEriRouterPairedToPortConnectionV1Read(d, config); err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+
+		if !strings.Contains(buf.String(), "[WARN] Connection F030123456789 is in Error state") {
+			t.Fatalf("expected a warning about the Error state, got %q", buf.String())
+		}
+
+		diff, err := r.Diff(d.State(), terraform.NewResourceConfigRaw(raw), nil)
+		if err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+
+		if requiresNew := diff != nil && diff.RequiresNew(); requiresNew != recreate {
+			t.Fatalf("recreate_on_error %t: expected replacement %t, got %t", recreate, recreate, requiresNew)
+		}
+	}
+}
+
 func TestAccEriRouterPairedToPortConnectionV1Basic(t *testing.T) {
 	var c connections.Connection
 
@@ -38,6 +305,11 @@ func TestAccEriRouterPairedToPortConnectionV1Basic(t *testing.T) {
 						"fic_eri_router_paired_to_port_connection_v1.connection_1", "name", "terraform_connection_1"),
 				),
 			},
+			resource.TestStep{
+				ResourceName:      "fic_eri_router_paired_to_port_connection_v1.connection_1",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
 		},
 	})
 }
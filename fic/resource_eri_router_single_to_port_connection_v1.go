@@ -6,7 +6,6 @@ import (
 	"strconv"
 	"time"
 
-	"github.com/hashicorp/terraform-plugin-sdk/helper/resource"
 	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/helper/validation"
 
@@ -20,19 +19,23 @@ func resourceEriRouterSingleToPortConnectionV1() *schema.Resource {
 		Update: resourceEriRouterSingleToPortConnectionV1Update,
 		Delete: resourceEriRouterSingleToPortConnectionV1Delete,
 		Importer: &schema.ResourceImporter{
-			State: schema.ImportStatePassthrough,
+			State: importRouterToPortConnection,
 		},
 
+		CustomizeDiff: resourceEriRouterSingleToPortConnectionV1CustomizeDiff,
+
 		Timeouts: &schema.ResourceTimeout{
 			Create: schema.DefaultTimeout(10 * time.Minute),
+			Update: schema.DefaultTimeout(10 * time.Minute),
 			Delete: schema.DefaultTimeout(10 * time.Minute),
 		},
 
 		Schema: map[string]*schema.Schema{
 			"name": &schema.Schema{
-				Type:     schema.TypeString,
-				Required: true,
-				ForceNew: true,
+				Type:         schema.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				ValidateFunc: ValidateFICName(),
 			},
 
 			"source_router_id": &schema.Schema{
@@ -80,14 +83,16 @@ func resourceEriRouterSingleToPortConnectionV1() *schema.Resource {
 							Required: true,
 						},
 						"as_path_prepend_in": &schema.Schema{
-							Type:     schema.TypeString,
-							Optional: true,
+							Type:             schema.TypeString,
+							Optional:         true,
+							DiffSuppressFunc: suppressASPathPrependOffDiff,
 							ValidateFunc: validation.StringInSlice(
 								[]string{"OFF", "1", "2", "3", "4", "5"}, false),
 						},
 						"as_path_prepend_out": &schema.Schema{
-							Type:     schema.TypeString,
-							Optional: true,
+							Type:             schema.TypeString,
+							Optional:         true,
+							DiffSuppressFunc: suppressASPathPrependOffDiff,
 							ValidateFunc: validation.StringInSlice(
 								[]string{"OFF", "1", "2", "3", "4", "5"}, false),
 						},
@@ -116,8 +121,10 @@ func resourceEriRouterSingleToPortConnectionV1() *schema.Resource {
 							Required: true,
 						},
 						"asn": &schema.Schema{
-							Type:     schema.TypeString,
-							Required: true,
+							Type:         schema.TypeString,
+							Required:     true,
+							ForceNew:     true,
+							ValidateFunc: ValidateASN(),
 						},
 					},
 				},
@@ -131,6 +138,7 @@ func resourceEriRouterSingleToPortConnectionV1() *schema.Resource {
 					"10M", "20M", "30M", "40M", "50M", "100M", "200M", "300M", "400M", "500M",
 					"1G", "2G", "3G", "4G", "5G", "10G",
 				}, false),
+				DiffSuppressFunc: suppressEquivalentBandwidthDiff,
 			},
 
 			"redundant": &schema.Schema{
@@ -143,10 +151,42 @@ func resourceEriRouterSingleToPortConnectionV1() *schema.Resource {
 				Computed: true,
 			},
 
+			"recreate_on_error": &schema.Schema{
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  false,
+			},
+
+			"adopt_existing": &schema.Schema{
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  false,
+			},
+
+			"operation_status": &schema.Schema{
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+
+			"created_at": &schema.Schema{
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+
 			"area": &schema.Schema{
 				Type:     schema.TypeString,
 				Computed: true,
 			},
+
+			"raw_json": &schema.Schema{
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+
+			"uri": &schema.Schema{
+				Type:     schema.TypeString,
+				Computed: true,
+			},
 		},
 	}
 }
@@ -212,14 +252,13 @@ func getSourceHAInfoOfRouterSingleToPortConnectionForUpdate(i map[string]interfa
 }
 
 func getSourceOfRouterSingleToPortConnection(d *schema.ResourceData) connections.Source {
-	tmpSource := d.Get("source_information").([]interface{})
-
-	tmpPrimary := tmpSource[0].(map[string]interface{})
-	primary := getSourceHAInfoOfRouterSingleToPortConnection(tmpPrimary)
 	source := connections.Source{
 		RouterID:  d.Get("source_router_id").(string),
 		GroupName: d.Get("source_group_name").(string),
-		Primary:   primary,
+	}
+
+	if tmpPrimary, ok := getOptionalBlock(d, "source_information"); ok {
+		source.Primary = getSourceHAInfoOfRouterSingleToPortConnection(tmpPrimary)
 	}
 
 	// tmpSecondary := tmpSource[1].(map[string]interface{})
@@ -235,12 +274,10 @@ func getSourceOfRouterSingleToPortConnection(d *schema.ResourceData) connections
 }
 
 func getSourceOfRouterSingleToPortConnectionForUpdate(d *schema.ResourceData) connections.SourceForUpdate {
-	tmpSource := d.Get("source_information").([]interface{})
+	source := connections.SourceForUpdate{}
 
-	tmpPrimary := tmpSource[0].(map[string]interface{})
-	primary := getSourceHAInfoOfRouterSingleToPortConnectionForUpdate(tmpPrimary)
-	source := connections.SourceForUpdate{
-		Primary: primary,
+	if tmpPrimary, ok := getOptionalBlock(d, "source_information"); ok {
+		source.Primary = getSourceHAInfoOfRouterSingleToPortConnectionForUpdate(tmpPrimary)
 	}
 
 	// tmpSecondary := tmpSource[1].(map[string]interface{})
@@ -256,17 +293,15 @@ func getSourceOfRouterSingleToPortConnectionForUpdate(d *schema.ResourceData) co
 }
 
 func getDestinationOfRouterSingleToPortConnection(d *schema.ResourceData) connections.Destination {
-	tmpDestination := d.Get("destination_information").([]interface{})
-
-	tmpPrimary := tmpDestination[0]
-	primary := connections.DestinationHAInfo{
-		PortID:    tmpPrimary.(map[string]interface{})["port_id"].(string),
-		VLAN:      tmpPrimary.(map[string]interface{})["vlan"].(int),
-		IPAddress: tmpPrimary.(map[string]interface{})["ip_address"].(string),
-		ASN:       tmpPrimary.(map[string]interface{})["asn"].(string),
-	}
-	destination := connections.Destination{
-		Primary: primary,
+	destination := connections.Destination{}
+
+	if tmpPrimary, ok := getOptionalBlock(d, "destination_information"); ok {
+		destination.Primary = connections.DestinationHAInfo{
+			PortID:    tmpPrimary["port_id"].(string),
+			VLAN:      tmpPrimary["vlan"].(int),
+			IPAddress: tmpPrimary["ip_address"].(string),
+			ASN:       tmpPrimary["asn"].(string),
+		}
 	}
 
 	// tmpSecondary := tmpDestination[1]
@@ -281,6 +316,12 @@ func getDestinationOfRouterSingleToPortConnection(d *schema.ResourceData) connec
 	return destination
 }
 
+func resourceEriRouterSingleToPortConnectionV1CustomizeDiff(d *schema.ResourceDiff, meta interface{}) error {
+	warnReplacedASNs(d, "destination_information.0.asn")
+
+	return customizeDiffRecreateOnError(d, meta)
+}
+
 func resourceEriRouterSingleToPortConnectionV1Create(d *schema.ResourceData, meta interface{}) error {
 	config := meta.(*Config)
 	client, err := config.eriV1Client(GetRegion(d, config))
@@ -288,6 +329,10 @@ func resourceEriRouterSingleToPortConnectionV1Create(d *schema.ResourceData, met
 		return fmt.Errorf("Error creating FIC ERI client: %s", err)
 	}
 
+	if adopted, err := adoptExistingConnection(d, meta, "router_to_port", resourceEriRouterSingleToPortConnectionV1()); err != nil || adopted {
+		return err
+	}
+
 	createOpts := &connections.CreateOpts{
 		Name:        d.Get("name").(string),
 		Source:      getSourceOfRouterSingleToPortConnection(d),
@@ -298,39 +343,34 @@ func resourceEriRouterSingleToPortConnectionV1Create(d *schema.ResourceData, met
 	log.Printf("[DEBUG] Create Options: %#v", createOpts)
 	r, err := connections.Create(client, createOpts).Extract()
 	if err != nil {
-		return fmt.Errorf("Error creating FIC ERI connection(router to port): %s", err)
+		return formatFICError(err, "Error creating FIC ERI connection(router to port)")
 	}
 
 	d.SetId(r.ID)
 
 	log.Printf("[INFO] Connection ID: %s", r.ID)
 
-	log.Printf(
-		"[DEBUG] Waiting for connection (%s) to become available", r.ID)
-
-	stateConf := &resource.StateChangeConf{
-		Pending:    []string{"Processing"},
-		Target:     []string{"Completed"},
-		Refresh:    RouterToPortConnectionV1StateRefreshFunc(client, r.ID),
-		Timeout:    d.Timeout(schema.TimeoutCreate),
-		Delay:      10 * time.Second,
-		MinTimeout: 3 * time.Second,
-	}
-
-	_, err = stateConf.WaitForState()
+	_, err = config.waitForActive(statusWait{
+		ResourceType: "connection",
+		ID:           r.ID,
+		Refresh:      RouterToPortConnectionV1StateRefreshFunc(client, r.ID),
+		Pending:      []string{"Processing"},
+		Target:       []string{"Completed"},
+		Timeout:      d.Timeout(schema.TimeoutCreate),
+	})
 	if err != nil {
 		return fmt.Errorf(
 			"Error waiting for connection (%s) to become ready: %s", r.ID, err)
 	}
 
-	return resourceEriRouterSingleToPortConnectionV1Read(d, meta)
+	return readAfterCreate(d, meta, resourceEriRouterSingleToPortConnectionV1Read)
 }
 
 func getSourceInformationOfRouterSingleToPortConnectionForState(r *connections.Connection) []map[string]interface{} {
 	primary := map[string]interface{}{
 		"ip_address":          r.Source.Primary.IPAddress,
-		"as_path_prepend_in":  r.Source.Primary.ASPathPrepend.In,
-		"as_path_prepend_out": r.Source.Primary.ASPathPrepend.Out,
+		"as_path_prepend_in":  flattenASPathPrepend(r.Source.Primary.ASPathPrepend.In),
+		"as_path_prepend_out": flattenASPathPrepend(r.Source.Primary.ASPathPrepend.Out),
 	}
 	// secondary := map[string]interface{}{
 	// 	"ip_address":          r.Source.Secondary.IPAddress,
@@ -376,6 +416,7 @@ func resourceEriRouterSingleToPortConnectionV1Read(d *schema.ResourceData, meta
 
 	log.Printf("[DEBUG] Retrieved connection %s: %+v", d.Id(), r)
 
+	d.Set("uri", client.ServiceURL("router-to-port-connections", d.Id()))
 	d.Set("name", r.Name)
 
 	d.Set("source_router_id", r.Source.RouterID)
@@ -390,8 +431,14 @@ func resourceEriRouterSingleToPortConnectionV1Read(d *schema.ResourceData, meta
 	d.Set("bandwidth", r.Bandwidth)
 	d.Set("redundant", r.Redundant)
 	d.Set("tenant_id", r.TenantID)
+	d.Set("operation_status", r.OperationStatus)
+	warnOnOperationError(d, r.OperationStatus)
 	d.Set("area", r.Area)
 
+	if err := setRawJSON(d, config, r); err != nil {
+		return err
+	}
+
 	return nil
 }
 
@@ -408,23 +455,17 @@ func resourceEriRouterSingleToPortConnectionV1Update(d *schema.ResourceData, met
 		}
 		_, err := connections.Update(client, d.Id(), updateOpts).Extract()
 		if err != nil {
-			return fmt.Errorf("Error activating FIC ERI connection: %s", err)
+			return formatFICError(err, "Error activating FIC ERI connection")
 		}
 
-		log.Printf(
-			"[DEBUG] Waiting for connection (%s) to become complete", d.Id())
-
-		stateConf := &resource.StateChangeConf{
-			Pending:    []string{"Processing"},
-			Target:     []string{"Completed"},
-			Refresh:    RouterToPortConnectionV1StateRefreshFunc(client, d.Id()),
-			Timeout:    d.Timeout(schema.TimeoutCreate),
-			Delay:      10 * time.Second,
-			MinTimeout: 3 * time.Second,
-		}
-
-		log.Printf("[DEBUG] Waiting for connection (%s) to become complete", d.Id())
-		_, err = stateConf.WaitForState()
+		_, err = waitForStatus(config.StopContext(), statusWait{
+			ResourceType: "connection",
+			ID:           d.Id(),
+			Refresh:      RouterToPortConnectionV1StateRefreshFunc(client, d.Id()),
+			Pending:      []string{"Processing"},
+			Target:       []string{"Completed"},
+			Timeout:      d.Timeout(schema.TimeoutUpdate),
+		})
 		if err != nil {
 			return fmt.Errorf("Error waiting for connection (%s) to become complete: %s", d.Id(), err)
 		}
@@ -444,18 +485,14 @@ func resourceEriRouterSingleToPortConnectionV1Delete(d *schema.ResourceData, met
 		return CheckDeleted(d, err, "connection")
 	}
 
-	log.Printf("[DEBUG] Waiting for connection (%s) to delete", d.Id())
-
-	stateConf := &resource.StateChangeConf{
-		Pending:    []string{"Processing", "Completed"},
-		Target:     []string{"Deleted"},
-		Refresh:    RouterToPortConnectionV1StateRefreshFunc(client, d.Id()),
-		Timeout:    d.Timeout(schema.TimeoutDelete),
-		Delay:      10 * time.Second,
-		MinTimeout: 3 * time.Second,
-	}
-
-	_, err = stateConf.WaitForState()
+	_, err = waitForStatus(config.StopContext(), statusWait{
+		ResourceType: "connection",
+		ID:           d.Id(),
+		Refresh:      RouterToPortConnectionV1StateRefreshFunc(client, d.Id()),
+		Pending:      []string{"Processing", "Completed"},
+		Target:       []string{"Deleted"},
+		Timeout:      d.Timeout(schema.TimeoutDelete),
+	})
 	if err != nil {
 		return fmt.Errorf(
 			"Error waiting for connection (%s) to delete: %s",
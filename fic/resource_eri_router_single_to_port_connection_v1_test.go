@@ -39,6 +39,11 @@ func TestAccEriRouterSingleToPortConnectionV1Basic(t *testing.T) {
 						"fic_eri_router_single_to_port_connection_v1.connection_1", "name", "terraform_connection_1"),
 				),
 			},
+			resource.TestStep{
+				ResourceName:      "fic_eri_router_single_to_port_connection_v1.connection_1",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
 		},
 	})
 }
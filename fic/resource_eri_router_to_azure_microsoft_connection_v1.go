@@ -22,16 +22,20 @@ func resourceEriRouterToAzureMicrosoftConnectionV1() *schema.Resource {
 			State: schema.ImportStatePassthrough,
 		},
 
+		CustomizeDiff: customizeDiffRecreateOnError,
+
 		Timeouts: &schema.ResourceTimeout{
 			Create: schema.DefaultTimeout(10 * time.Minute),
+			Update: schema.DefaultTimeout(10 * time.Minute),
 			Delete: schema.DefaultTimeout(10 * time.Minute),
 		},
 
 		Schema: map[string]*schema.Schema{
 			"name": &schema.Schema{
-				Type:     schema.TypeString,
-				Required: true,
-				ForceNew: true,
+				Type:         schema.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				ValidateFunc: ValidateFICName(),
 			},
 
 			"source_router_id": &schema.Schema{
@@ -79,15 +83,20 @@ func resourceEriRouterToAzureMicrosoftConnectionV1() *schema.Resource {
 			},
 
 			"destination_service_key": &schema.Schema{
-				Type:     schema.TypeString,
-				Required: true,
-				ForceNew: true,
+				Type:      schema.TypeString,
+				Required:  true,
+				ForceNew:  true,
+				Sensitive: true,
 			},
 
 			"destination_advertised_public_prefixes": &schema.Schema{
 				Type:     schema.TypeList,
 				Required: true,
-				Elem:     &schema.Schema{Type: schema.TypeString},
+				Elem: &schema.Schema{
+					Type:             schema.TypeString,
+					ValidateFunc:     ValidateCIDR(),
+					DiffSuppressFunc: suppressEquivalentCIDRDiff,
+				},
 			},
 
 			"primary_connected_network_address": &schema.Schema{
@@ -110,6 +119,7 @@ func resourceEriRouterToAzureMicrosoftConnectionV1() *schema.Resource {
 					"1G", "2G", "3G", "4G", "5G",
 					"10G",
 				}, false),
+				DiffSuppressFunc: suppressEquivalentBandwidthDiff,
 			},
 
 			"redundant": &schema.Schema{
@@ -132,10 +142,37 @@ func resourceEriRouterToAzureMicrosoftConnectionV1() *schema.Resource {
 				Computed: true,
 			},
 
+			"recreate_on_error": &schema.Schema{
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  false,
+			},
+
+			"adopt_existing": &schema.Schema{
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  false,
+			},
+
 			"operation_status": &schema.Schema{
 				Type:     schema.TypeString,
 				Computed: true,
 			},
+
+			"created_at": &schema.Schema{
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+
+			"raw_json": &schema.Schema{
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+
+			"uri": &schema.Schema{
+				Type:     schema.TypeString,
+				Computed: true,
+			},
 		},
 	}
 }
@@ -147,6 +184,10 @@ func resourceEriRouterToAzureMicrosoftConnectionV1Create(d *schema.ResourceData,
 		return fmt.Errorf("Error creating FIC ERI client: %s", err)
 	}
 
+	if adopted, err := adoptExistingConnection(d, meta, "router_to_azure_microsoft", resourceEriRouterToAzureMicrosoftConnectionV1()); err != nil || adopted {
+		return err
+	}
+
 	routeFilter := connections.RouteFilter{
 		In:  d.Get("source_route_filter_in").(string),
 		Out: d.Get("source_route_filter_out").(string),
@@ -180,32 +221,27 @@ func resourceEriRouterToAzureMicrosoftConnectionV1Create(d *schema.ResourceData,
 	log.Printf("[DEBUG] Create Options: %#v", createOpts)
 	r, err := connections.Create(client, createOpts).Extract()
 	if err != nil {
-		return fmt.Errorf("Error creating FIC ERI router to azure microsoft connection: %s", err)
+		return formatFICError(err, "Error creating FIC ERI router to azure microsoft connection")
 	}
 
 	d.SetId(r.ID)
 
 	log.Printf("[INFO] Connection ID: %s", r.ID)
 
-	log.Printf(
-		"[DEBUG] Waiting for router to azure microsoft connection (%s) to become available", r.ID)
-
-	stateConf := &resource.StateChangeConf{
-		Pending:    []string{"Processing"},
-		Target:     []string{"Completed"},
-		Refresh:    resourceRouterToAzureMicrosoftConnectionV1StateRefreshFunc(client, r.ID),
-		Timeout:    d.Timeout(schema.TimeoutCreate),
-		Delay:      10 * time.Second,
-		MinTimeout: 3 * time.Second,
-	}
-
-	_, err = stateConf.WaitForState()
+	_, err = config.waitForActive(statusWait{
+		ResourceType: "connection",
+		ID:           r.ID,
+		Refresh:      resourceRouterToAzureMicrosoftConnectionV1StateRefreshFunc(client, r.ID),
+		Pending:      []string{"Processing"},
+		Target:       []string{"Completed"},
+		Timeout:      d.Timeout(schema.TimeoutCreate),
+	})
 	if err != nil {
 		return fmt.Errorf(
 			"Error waiting for router to azure microsoft connection (%s) to become ready: %s", r.ID, err)
 	}
 
-	return resourceEriRouterToAzureMicrosoftConnectionV1Read(d, meta)
+	return readAfterCreate(d, meta, resourceEriRouterToAzureMicrosoftConnectionV1Read)
 }
 
 func resourceEriRouterToAzureMicrosoftConnectionV1Read(d *schema.ResourceData, meta interface{}) error {
@@ -222,6 +258,7 @@ func resourceEriRouterToAzureMicrosoftConnectionV1Read(d *schema.ResourceData, m
 
 	log.Printf("[DEBUG] Retrieved router to azure microsoft connection %s: %+v", d.Id(), r)
 
+	d.Set("uri", client.ServiceURL("router-to-azure-microsoft-connections", d.Id()))
 	d.Set("name", r.Name)
 
 	d.Set("source_router_id", r.Source.RouterID)
@@ -240,8 +277,14 @@ func resourceEriRouterToAzureMicrosoftConnectionV1Read(d *schema.ResourceData, m
 	d.Set("bandwidth", r.Bandwidth)
 	d.Set("redundant", r.Redundant)
 	d.Set("tenant_id", r.TenantID)
+	d.Set("operation_status", r.OperationStatus)
+	warnOnOperationError(d, r.OperationStatus)
 	d.Set("area", r.Area)
 
+	if err := setRawJSON(d, config, r); err != nil {
+		return err
+	}
+
 	return nil
 }
 
@@ -278,23 +321,17 @@ func resourceEriRouterToAzureMicrosoftConnectionV1Update(d *schema.ResourceData,
 
 		_, err := connections.Update(client, d.Id(), updateOpts).Extract()
 		if err != nil {
-			return fmt.Errorf("Error updating FIC ERI router to azure microsoft connection: %s", err)
+			return formatFICError(err, "Error updating FIC ERI router to azure microsoft connection")
 		}
 
-		log.Printf(
-			"[DEBUG] Waiting for router to azure microsoft connection (%s) to become complete", d.Id())
-
-		stateConf := &resource.StateChangeConf{
-			Pending:    []string{"Processing"},
-			Target:     []string{"Completed"},
-			Refresh:    resourceRouterToAzureMicrosoftConnectionV1StateRefreshFunc(client, d.Id()),
-			Timeout:    d.Timeout(schema.TimeoutCreate),
-			Delay:      10 * time.Second,
-			MinTimeout: 3 * time.Second,
-		}
-
-		log.Printf("[DEBUG] Waiting for router to azure microsoft connection (%s) to become complete", d.Id())
-		_, err = stateConf.WaitForState()
+		_, err = waitForStatus(config.StopContext(), statusWait{
+			ResourceType: "connection",
+			ID:           d.Id(),
+			Refresh:      resourceRouterToAzureMicrosoftConnectionV1StateRefreshFunc(client, d.Id()),
+			Pending:      []string{"Processing"},
+			Target:       []string{"Completed"},
+			Timeout:      d.Timeout(schema.TimeoutUpdate),
+		})
 		if err != nil {
 			return fmt.Errorf("Error waiting for router to azure microsoft connection (%s) to become complete: %s", d.Id(), err)
 		}
@@ -314,18 +351,14 @@ func resourceEriRouterToAzureMicrosoftConnectionV1Delete(d *schema.ResourceData,
 		return CheckDeleted(d, err, "connection")
 	}
 
-	log.Printf("[DEBUG] Waiting for router to azure microsoft connection (%s) to delete", d.Id())
-
-	stateConf := &resource.StateChangeConf{
-		Pending:    []string{"Processing", "Completed"},
-		Target:     []string{"Deleted"},
-		Refresh:    resourceRouterToAzureMicrosoftConnectionV1StateRefreshFunc(client, d.Id()),
-		Timeout:    d.Timeout(schema.TimeoutDelete),
-		Delay:      10 * time.Second,
-		MinTimeout: 3 * time.Second,
-	}
-
-	_, err = stateConf.WaitForState()
+	_, err = waitForStatus(config.StopContext(), statusWait{
+		ResourceType: "connection",
+		ID:           d.Id(),
+		Refresh:      resourceRouterToAzureMicrosoftConnectionV1StateRefreshFunc(client, d.Id()),
+		Pending:      []string{"Processing", "Completed"},
+		Target:       []string{"Deleted"},
+		Timeout:      d.Timeout(schema.TimeoutDelete),
+	})
 	if err != nil {
 		return fmt.Errorf(
 			"Error waiting for router to azure microsoft connection (%s) to delete: %s",
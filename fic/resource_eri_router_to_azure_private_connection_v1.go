@@ -22,16 +22,20 @@ func resourceEriRouterToAzurePrivateConnectionV1() *schema.Resource {
 			State: schema.ImportStatePassthrough,
 		},
 
+		CustomizeDiff: customizeDiffRecreateOnError,
+
 		Timeouts: &schema.ResourceTimeout{
 			Create: schema.DefaultTimeout(10 * time.Minute),
+			Update: schema.DefaultTimeout(10 * time.Minute),
 			Delete: schema.DefaultTimeout(10 * time.Minute),
 		},
 
 		Schema: map[string]*schema.Schema{
 			"name": &schema.Schema{
-				Type:     schema.TypeString,
-				Required: true,
-				ForceNew: true,
+				Type:         schema.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				ValidateFunc: ValidateFICName(),
 			},
 
 			"source_router_id": &schema.Schema{
@@ -79,21 +83,26 @@ func resourceEriRouterToAzurePrivateConnectionV1() *schema.Resource {
 			},
 
 			"destination_service_key": &schema.Schema{
-				Type:     schema.TypeString,
-				Required: true,
-				ForceNew: true,
+				Type:      schema.TypeString,
+				Required:  true,
+				ForceNew:  true,
+				Sensitive: true,
 			},
 
 			"primary_connected_network_address": &schema.Schema{
-				Type:     schema.TypeString,
-				Required: true,
-				ForceNew: true,
+				Type:             schema.TypeString,
+				Required:         true,
+				ForceNew:         true,
+				ValidateFunc:     ValidateCIDR(),
+				DiffSuppressFunc: suppressEquivalentCIDRDiff,
 			},
 
 			"secondary_connected_network_address": &schema.Schema{
-				Type:     schema.TypeString,
-				Required: true,
-				ForceNew: true,
+				Type:             schema.TypeString,
+				Required:         true,
+				ForceNew:         true,
+				ValidateFunc:     ValidateCIDR(),
+				DiffSuppressFunc: suppressEquivalentCIDRDiff,
 			},
 
 			"bandwidth": &schema.Schema{
@@ -106,6 +115,7 @@ func resourceEriRouterToAzurePrivateConnectionV1() *schema.Resource {
 					"1G", "2G", "3G", "4G", "5G",
 					"10G",
 				}, false),
+				DiffSuppressFunc: suppressEquivalentBandwidthDiff,
 			},
 
 			"redundant": &schema.Schema{
@@ -128,10 +138,37 @@ func resourceEriRouterToAzurePrivateConnectionV1() *schema.Resource {
 				Computed: true,
 			},
 
+			"recreate_on_error": &schema.Schema{
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  false,
+			},
+
+			"adopt_existing": &schema.Schema{
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  false,
+			},
+
 			"operation_status": &schema.Schema{
 				Type:     schema.TypeString,
 				Computed: true,
 			},
+
+			"created_at": &schema.Schema{
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+
+			"raw_json": &schema.Schema{
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+
+			"uri": &schema.Schema{
+				Type:     schema.TypeString,
+				Computed: true,
+			},
 		},
 	}
 }
@@ -143,6 +180,10 @@ func resourceEriRouterToAzurePrivateConnectionV1Create(d *schema.ResourceData, m
 		return fmt.Errorf("Error creating FIC ERI client: %s", err)
 	}
 
+	if adopted, err := adoptExistingConnection(d, meta, "router_to_azure_private", resourceEriRouterToAzurePrivateConnectionV1()); err != nil || adopted {
+		return err
+	}
+
 	routeFilter := connections.RouteFilter{
 		In:  d.Get("source_route_filter_in").(string),
 		Out: d.Get("source_route_filter_out").(string),
@@ -172,32 +213,27 @@ func resourceEriRouterToAzurePrivateConnectionV1Create(d *schema.ResourceData, m
 	log.Printf("[DEBUG] Create Options: %#v", createOpts)
 	r, err := connections.Create(client, createOpts).Extract()
 	if err != nil {
-		return fmt.Errorf("Error creating FIC ERI router to azure private connection: %s", err)
+		return formatFICError(err, "Error creating FIC ERI router to azure private connection")
 	}
 
 	d.SetId(r.ID)
 
 	log.Printf("[INFO] Connection ID: %s", r.ID)
 
-	log.Printf(
-		"[DEBUG] Waiting for router to azure private connection (%s) to become available", r.ID)
-
-	stateConf := &resource.StateChangeConf{
-		Pending:    []string{"Processing"},
-		Target:     []string{"Completed"},
-		Refresh:    resourceRouterToAzurePrivateConnectionV1StateRefreshFunc(client, r.ID),
-		Timeout:    d.Timeout(schema.TimeoutCreate),
-		Delay:      10 * time.Second,
-		MinTimeout: 3 * time.Second,
-	}
-
-	_, err = stateConf.WaitForState()
+	_, err = config.waitForActive(statusWait{
+		ResourceType: "connection",
+		ID:           r.ID,
+		Refresh:      resourceRouterToAzurePrivateConnectionV1StateRefreshFunc(client, r.ID),
+		Pending:      []string{"Processing"},
+		Target:       []string{"Completed"},
+		Timeout:      d.Timeout(schema.TimeoutCreate),
+	})
 	if err != nil {
 		return fmt.Errorf(
 			"Error waiting for router to azure private connection (%s) to become ready: %s", r.ID, err)
 	}
 
-	return resourceEriRouterToAzurePrivateConnectionV1Read(d, meta)
+	return readAfterCreate(d, meta, resourceEriRouterToAzurePrivateConnectionV1Read)
 }
 
 func resourceEriRouterToAzurePrivateConnectionV1Read(d *schema.ResourceData, meta interface{}) error {
@@ -214,6 +250,7 @@ func resourceEriRouterToAzurePrivateConnectionV1Read(d *schema.ResourceData, met
 
 	log.Printf("[DEBUG] Retrieved router to azure private connection %s: %+v", d.Id(), r)
 
+	d.Set("uri", client.ServiceURL("router-to-azure-private-connections", d.Id()))
 	d.Set("name", r.Name)
 
 	d.Set("source_router_id", r.Source.RouterID)
@@ -231,8 +268,14 @@ func resourceEriRouterToAzurePrivateConnectionV1Read(d *schema.ResourceData, met
 	d.Set("bandwidth", r.Bandwidth)
 	d.Set("redundant", r.Redundant)
 	d.Set("tenant_id", r.TenantID)
+	d.Set("operation_status", r.OperationStatus)
+	warnOnOperationError(d, r.OperationStatus)
 	d.Set("area", r.Area)
 
+	if err := setRawJSON(d, config, r); err != nil {
+		return err
+	}
+
 	return nil
 }
 
@@ -259,23 +302,17 @@ func resourceEriRouterToAzurePrivateConnectionV1Update(d *schema.ResourceData, m
 
 		_, err := connections.Update(client, d.Id(), updateOpts).Extract()
 		if err != nil {
-			return fmt.Errorf("Error updating FIC ERI router to azure private connection: %s", err)
+			return formatFICError(err, "Error updating FIC ERI router to azure private connection")
 		}
 
-		log.Printf(
-			"[DEBUG] Waiting for router to azure private connection (%s) to become complete", d.Id())
-
-		stateConf := &resource.StateChangeConf{
-			Pending:    []string{"Processing"},
-			Target:     []string{"Completed"},
-			Refresh:    resourceRouterToAzurePrivateConnectionV1StateRefreshFunc(client, d.Id()),
-			Timeout:    d.Timeout(schema.TimeoutCreate),
-			Delay:      10 * time.Second,
-			MinTimeout: 3 * time.Second,
-		}
-
-		log.Printf("[DEBUG] Waiting for router to azure private connection (%s) to become complete", d.Id())
-		_, err = stateConf.WaitForState()
+		_, err = waitForStatus(config.StopContext(), statusWait{
+			ResourceType: "connection",
+			ID:           d.Id(),
+			Refresh:      resourceRouterToAzurePrivateConnectionV1StateRefreshFunc(client, d.Id()),
+			Pending:      []string{"Processing"},
+			Target:       []string{"Completed"},
+			Timeout:      d.Timeout(schema.TimeoutUpdate),
+		})
 		if err != nil {
 			return fmt.Errorf("Error waiting for router to azure private connection (%s) to become complete: %s", d.Id(), err)
 		}
@@ -295,18 +332,14 @@ func resourceEriRouterToAzurePrivateConnectionV1Delete(d *schema.ResourceData, m
 		return CheckDeleted(d, err, "connection")
 	}
 
-	log.Printf("[DEBUG] Waiting for router to azure private connection (%s) to delete", d.Id())
-
-	stateConf := &resource.StateChangeConf{
-		Pending:    []string{"Processing", "Completed"},
-		Target:     []string{"Deleted"},
-		Refresh:    resourceRouterToAzurePrivateConnectionV1StateRefreshFunc(client, d.Id()),
-		Timeout:    d.Timeout(schema.TimeoutDelete),
-		Delay:      10 * time.Second,
-		MinTimeout: 3 * time.Second,
-	}
-
-	_, err = stateConf.WaitForState()
+	_, err = waitForStatus(config.StopContext(), statusWait{
+		ResourceType: "connection",
+		ID:           d.Id(),
+		Refresh:      resourceRouterToAzurePrivateConnectionV1StateRefreshFunc(client, d.Id()),
+		Pending:      []string{"Processing", "Completed"},
+		Target:       []string{"Deleted"},
+		Timeout:      d.Timeout(schema.TimeoutDelete),
+	})
 	if err != nil {
 		return fmt.Errorf(
 			"Error waiting for router to azure private connection (%s) to delete: %s",
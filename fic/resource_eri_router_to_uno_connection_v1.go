@@ -23,16 +23,20 @@ func resourceEriRouterToUNOConnectionV1() *schema.Resource {
 			State: schema.ImportStatePassthrough,
 		},
 
+		CustomizeDiff: customizeDiffRecreateOnError,
+
 		Timeouts: &schema.ResourceTimeout{
 			Create: schema.DefaultTimeout(10 * time.Minute),
+			Update: schema.DefaultTimeout(10 * time.Minute),
 			Delete: schema.DefaultTimeout(10 * time.Minute),
 		},
 
 		Schema: map[string]*schema.Schema{
 			"name": &schema.Schema{
-				Type:     schema.TypeString,
-				Required: true,
-				ForceNew: true,
+				Type:         schema.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				ValidateFunc: ValidateFICName(),
 			},
 
 			"source_router_id": &schema.Schema{
@@ -108,9 +112,11 @@ func resourceEriRouterToUNOConnectionV1() *schema.Resource {
 			},
 
 			"connected_network_address": &schema.Schema{
-				Type:     schema.TypeString,
-				Required: true,
-				ForceNew: true,
+				Type:             schema.TypeString,
+				Required:         true,
+				ForceNew:         true,
+				ValidateFunc:     ValidateCIDR(),
+				DiffSuppressFunc: suppressEquivalentCIDRDiff,
 			},
 
 			"bandwidth": &schema.Schema{
@@ -122,6 +128,7 @@ func resourceEriRouterToUNOConnectionV1() *schema.Resource {
 					"200M", "300M", "400M", "500M",
 					"1G",
 				}, false),
+				DiffSuppressFunc: suppressEquivalentBandwidthDiff,
 			},
 
 			"destination_contract_number": &schema.Schema{
@@ -139,10 +146,42 @@ func resourceEriRouterToUNOConnectionV1() *schema.Resource {
 				Computed: true,
 			},
 
+			"recreate_on_error": &schema.Schema{
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  false,
+			},
+
+			"adopt_existing": &schema.Schema{
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  false,
+			},
+
+			"operation_status": &schema.Schema{
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+
+			"created_at": &schema.Schema{
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+
 			"area": &schema.Schema{
 				Type:     schema.TypeString,
 				Computed: true,
 			},
+
+			"raw_json": &schema.Schema{
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+
+			"uri": &schema.Schema{
+				Type:     schema.TypeString,
+				Computed: true,
+			},
 		},
 	}
 }
@@ -154,6 +193,10 @@ func resourceEriRouterToUNOConnectionV1Create(d *schema.ResourceData, meta inter
 		return fmt.Errorf("Error creating FIC ERI client: %s", err)
 	}
 
+	if adopted, err := adoptExistingConnection(d, meta, "router_to_uno", resourceEriRouterToUNOConnectionV1()); err != nil || adopted {
+		return err
+	}
+
 	sourceRouteFilter := connections.SourceRouteFilter{
 		In:  d.Get("source_route_filter_in").(string),
 		Out: d.Get("source_route_filter_out").(string),
@@ -189,26 +232,21 @@ func resourceEriRouterToUNOConnectionV1Create(d *schema.ResourceData, meta inter
 	log.Printf("[DEBUG] Create Options: %#v", createOpts)
 	r, err := connections.Create(client, createOpts).Extract()
 	if err != nil {
-		return fmt.Errorf("Error creating FIC ERI connection(router to uno): %s", err)
+		return formatFICError(err, "Error creating FIC ERI connection(router to uno)")
 	}
 
 	d.SetId(r.ID)
 
 	log.Printf("[INFO] Connection ID: %s", r.ID)
 
-	log.Printf(
-		"[DEBUG] Waiting for connection (%s) to become available", r.ID)
-
-	stateConf := &resource.StateChangeConf{
-		Pending:    []string{"Processing"},
-		Target:     []string{"Completed"},
-		Refresh:    RouterToUNOConnectionV1StateRefreshFunc(client, r.ID),
-		Timeout:    d.Timeout(schema.TimeoutCreate),
-		Delay:      10 * time.Second,
-		MinTimeout: 3 * time.Second,
-	}
-
-	_, err = stateConf.WaitForState()
+	_, err = config.waitForActive(statusWait{
+		ResourceType: "connection",
+		ID:           r.ID,
+		Refresh:      RouterToUNOConnectionV1StateRefreshFunc(client, r.ID),
+		Pending:      []string{"Processing"},
+		Target:       []string{"Completed"},
+		Timeout:      d.Timeout(schema.TimeoutCreate),
+	})
 	if err != nil {
 		return fmt.Errorf(
 			"Error waiting for connection (%s) to become ready: %s", r.ID, err)
@@ -218,7 +256,7 @@ func resourceEriRouterToUNOConnectionV1Create(d *schema.ResourceData, meta inter
 	// this value, so this point is only change to store c number into state.
 	log.Printf("[DEBUG] Preserve destination_c_number as: %#v", d.Get("destination_c_number").(string))
 	d.Set("destination_c_number", d.Get("destination_c_number").(string))
-	return resourceEriRouterToUNOConnectionV1Read(d, meta)
+	return readAfterCreate(d, meta, resourceEriRouterToUNOConnectionV1Read)
 }
 
 func resourceEriRouterToUNOConnectionV1Read(d *schema.ResourceData, meta interface{}) error {
@@ -235,6 +273,7 @@ func resourceEriRouterToUNOConnectionV1Read(d *schema.ResourceData, meta interfa
 
 	log.Printf("[DEBUG] Retrieved connection %s: %+v", d.Id(), r)
 
+	d.Set("uri", client.ServiceURL("router-to-uno-connections", d.Id()))
 	d.Set("name", r.Name)
 
 	d.Set("source_router_id", r.Source.RouterID)
@@ -252,6 +291,7 @@ func resourceEriRouterToUNOConnectionV1Read(d *schema.ResourceData, meta interfa
 	d.Set("destination_contract_number", r.Destination.ContractNumber)
 	d.Set("destination_parent_contract_number", r.Destination.ParentContractNumber)
 	d.Set("destination_vpn_number", r.Destination.VPNNumber)
+	d.Set("destination_qos_type", r.Destination.QosType)
 	d.Set("destination_route_filter_out", r.Destination.RouteFilter.Out)
 
 	d.Set("connected_network_address", r.ConnectedNetworkAddress)
@@ -259,6 +299,12 @@ func resourceEriRouterToUNOConnectionV1Read(d *schema.ResourceData, meta interfa
 	d.Set("bandwidth", r.Bandwidth)
 	d.Set("redundant", r.Redundant)
 	d.Set("tenant_id", r.TenantID)
+	d.Set("operation_status", r.OperationStatus)
+	warnOnOperationError(d, r.OperationStatus)
+
+	if err := setRawJSON(d, config, r); err != nil {
+		return err
+	}
 
 	return nil
 }
@@ -291,23 +337,17 @@ func resourceEriRouterToUNOConnectionV1Update(d *schema.ResourceData, meta inter
 		log.Printf("[DEBUG] Update Options: %#v", updateOpts)
 		_, err := connections.Update(client, d.Id(), updateOpts).Extract()
 		if err != nil {
-			return fmt.Errorf("Error activating FIC ERI connection: %s", err)
+			return formatFICError(err, "Error activating FIC ERI connection")
 		}
 
-		log.Printf(
-			"[DEBUG] Waiting for connection (%s) to become complete", d.Id())
-
-		stateConf := &resource.StateChangeConf{
-			Pending:    []string{"Processing"},
-			Target:     []string{"Completed"},
-			Refresh:    RouterToUNOConnectionV1StateRefreshFunc(client, d.Id()),
-			Timeout:    d.Timeout(schema.TimeoutCreate),
-			Delay:      10 * time.Second,
-			MinTimeout: 3 * time.Second,
-		}
-
-		log.Printf("[DEBUG] Waiting for connection (%s) to become complete", d.Id())
-		_, err = stateConf.WaitForState()
+		_, err = waitForStatus(config.StopContext(), statusWait{
+			ResourceType: "connection",
+			ID:           d.Id(),
+			Refresh:      RouterToUNOConnectionV1StateRefreshFunc(client, d.Id()),
+			Pending:      []string{"Processing"},
+			Target:       []string{"Completed"},
+			Timeout:      d.Timeout(schema.TimeoutUpdate),
+		})
 		if err != nil {
 			return fmt.Errorf("Error waiting for connection (%s) to become complete: %s", d.Id(), err)
 		}
@@ -327,18 +367,14 @@ func resourceEriRouterToUNOConnectionV1Delete(d *schema.ResourceData, meta inter
 		return CheckDeleted(d, err, "connection")
 	}
 
-	log.Printf("[DEBUG] Waiting for connection (%s) to delete", d.Id())
-
-	stateConf := &resource.StateChangeConf{
-		Pending:    []string{"Processing", "Completed"},
-		Target:     []string{"Deleted"},
-		Refresh:    RouterToUNOConnectionV1StateRefreshFunc(client, d.Id()),
-		Timeout:    d.Timeout(schema.TimeoutDelete),
-		Delay:      10 * time.Second,
-		MinTimeout: 3 * time.Second,
-	}
-
-	_, err = stateConf.WaitForState()
+	_, err = waitForStatus(config.StopContext(), statusWait{
+		ResourceType: "connection",
+		ID:           d.Id(),
+		Refresh:      RouterToUNOConnectionV1StateRefreshFunc(client, d.Id()),
+		Pending:      []string{"Processing", "Completed"},
+		Target:       []string{"Deleted"},
+		Timeout:      d.Timeout(schema.TimeoutDelete),
+	})
 	if err != nil {
 		return fmt.Errorf(
 			"Error waiting for connection (%s) to delete: %s",
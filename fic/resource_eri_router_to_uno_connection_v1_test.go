@@ -2,14 +2,41 @@ package fic
 
 import (
 	"fmt"
+	"net/http"
 	"testing"
 
 	"github.com/hashicorp/terraform-plugin-sdk/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/terraform"
 
 	connections "github.com/nttcom/go-fic/fic/eri/v1/router_to_uno_connections"
 )
 
+func TestResourceEriRouterToUNOConnectionV1ReadQosType(t *testing.T) {
+	config, closeServer := testMockConfig(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		if r.URL.Path != "/v1/router-to-uno-connections/F030123456789" {
+			fmt.Fprint(w, `{"operations": []}`)
+			return
+		}
+		fmt.Fprint(w, `{"connection": {"id": "F030123456789", "name": "terraform_connection_1", "operationStatus": "Completed",
+			"destination": {"interconnect": "Tokyo-1", "qosType": "guarantee"}}}`)
+	}))
+	defer closeServer()
+
+	r := resourceEriRouterToUNOConnectionV1()
+	d := schema.TestResourceDataRaw(t, r.Schema, map[string]interface{}{})
+	d.SetId("F030123456789")
+
+	if err := resourceEriRouterToUNOConnectionV1Read(d, config); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	if v := d.Get("destination_qos_type").(string); v != "guarantee" {
+		t.Fatalf("expected destination_qos_type guarantee, got %q", v)
+	}
+}
+
 func TestAccEriRouterToUNOConnectionV1Basic(t *testing.T) {
 	var c connections.Connection
 
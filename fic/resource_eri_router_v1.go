@@ -1,6 +1,7 @@
 package fic
 
 import (
+	"context"
 	"errors"
 	"fmt"
 	"log"
@@ -17,6 +18,7 @@ func resourceEriRouterV1() *schema.Resource {
 	return &schema.Resource{
 		Create: resourceEriRouterV1Create,
 		Read:   resourceEriRouterV1Read,
+		Update: resourceEriRouterV1Update,
 		Delete: resourceEriRouterV1Delete,
 		Importer: &schema.ResourceImporter{
 			State: schema.ImportStatePassthrough,
@@ -24,26 +26,31 @@ func resourceEriRouterV1() *schema.Resource {
 
 		Timeouts: &schema.ResourceTimeout{
 			Create: schema.DefaultTimeout(10 * time.Minute),
+			Update: schema.DefaultTimeout(10 * time.Minute),
 			Delete: schema.DefaultTimeout(10 * time.Minute),
 		},
 
 		Schema: map[string]*schema.Schema{
 			"name": &schema.Schema{
-				Type:     schema.TypeString,
-				Required: true,
-				ForceNew: true,
+				Type:         schema.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				ValidateFunc: ValidateFICName(),
 			},
 
 			"area": &schema.Schema{
-				Type:     schema.TypeString,
-				Required: true,
-				ForceNew: true,
+				Type:             schema.TypeString,
+				Required:         true,
+				ForceNew:         true,
+				DiffSuppressFunc: suppressCaseInsensitiveDiff,
 			},
 
 			"user_ip_address": &schema.Schema{
-				Type:     schema.TypeString,
-				Required: true,
-				ForceNew: true,
+				Type:             schema.TypeString,
+				Required:         true,
+				ForceNew:         true,
+				ValidateFunc:     ValidateCIDR(),
+				DiffSuppressFunc: suppressEquivalentCIDRDiff,
 			},
 
 			"redundant": &schema.Schema{
@@ -53,11 +60,22 @@ func resourceEriRouterV1() *schema.Resource {
 				ForceNew: true,
 			},
 
+			"force_delete": &schema.Schema{
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  false,
+			},
+
 			"tenant_id": &schema.Schema{
 				Type:     schema.TypeString,
 				Computed: true,
 			},
 
+			"operation_status": &schema.Schema{
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+
 			"firewalls": &schema.Schema{
 				Type:     schema.TypeList,
 				Computed: true,
@@ -114,6 +132,11 @@ func resourceEriRouterV1() *schema.Resource {
 				Type:     schema.TypeString,
 				Computed: true,
 			},
+
+			"uri": &schema.Schema{
+				Type:     schema.TypeString,
+				Computed: true,
+			},
 		},
 	}
 }
@@ -125,37 +148,37 @@ func resourceEriRouterV1Create(d *schema.ResourceData, meta interface{}) error {
 		return fmt.Errorf("Error creating FIC ERI client: %s", err)
 	}
 
-	redundant := d.Get("redundant").(bool)
+	// redundant is required by the API, so an explicit false is always sent
+	redundant := GetBoolPtr(d, "redundant")
+	if redundant == nil {
+		redundant = new(bool)
+	}
+
 	createOpts := &routers.CreateOpts{
 		Name:          d.Get("name").(string),
 		Area:          d.Get("area").(string),
 		UserIPAddress: d.Get("user_ip_address").(string),
-		Redundant:     &redundant,
+		Redundant:     redundant,
 	}
 
 	log.Printf("[DEBUG] Create Options: %#v", createOpts)
 	r, err := routers.Create(client, createOpts).Extract()
 	if err != nil {
-		return fmt.Errorf("Error creating FIC ERI router: %s", err)
+		return formatFICError(err, "Error creating FIC ERI router")
 	}
 
 	d.SetId(r.ID)
 
 	log.Printf("[INFO] Router ID: %s", r.ID)
 
-	log.Printf(
-		"[DEBUG] Waiting for router (%s) to become available", r.ID)
-
-	stateConf := &resource.StateChangeConf{
-		Pending:    []string{"Processing"},
-		Target:     []string{"Completed"},
-		Refresh:    RouterV1StateRefreshFunc(client, r.ID),
-		Timeout:    d.Timeout(schema.TimeoutCreate),
-		Delay:      10 * time.Second,
-		MinTimeout: 3 * time.Second,
-	}
-
-	_, err = stateConf.WaitForState()
+	_, err = config.waitForActive(statusWait{
+		ResourceType: "router",
+		ID:           r.ID,
+		Refresh:      RouterV1StateRefreshFunc(client, r.ID),
+		Pending:      []string{"Processing"},
+		Target:       []string{"Completed"},
+		Timeout:      d.Timeout(schema.TimeoutCreate),
+	})
 	if err != nil {
 		return fmt.Errorf(
 			"Error waiting for router (%s) to become ready: %s", r.ID, err)
@@ -213,24 +236,34 @@ func resourceEriRouterV1Read(d *schema.ResourceData, meta interface{}) error {
 
 	log.Printf("[DEBUG] Retrieved router %s: %+v", d.Id(), r)
 
+	d.Set("uri", client.ServiceURL("routers", d.Id()))
 	d.Set("name", r.Name)
 	d.Set("area", r.Area)
 	d.Set("user_ip_address", r.UserIPAddress)
 	d.Set("redundant", r.Redundant)
 	d.Set("tenant_id", r.TenantID)
+	d.Set("operation_status", r.OperationStatus)
 	d.Set("firewalls", getRouterFirewallForState(r))
 	d.Set("nats", getRouterNATForState(r))
 	d.Set("routing_groups", getRoutingGroupForState(r))
 
-	firewallID := r.Firewalls[0].ID
-	natID := r.NATs[0].ID
+	if len(r.Firewalls) > 0 {
+		d.Set("firewall_id", r.Firewalls[0].ID)
+	}
 
-	d.Set("firewall_id", firewallID)
-	d.Set("nat_id", natID)
+	if len(r.NATs) > 0 {
+		d.Set("nat_id", r.NATs[0].ID)
+	}
 
 	return nil
 }
 
+// resourceEriRouterV1Update only saves force_delete, all other arguments
+// force a new router.
+func resourceEriRouterV1Update(d *schema.ResourceData, meta interface{}) error {
+	return resourceEriRouterV1Read(d, meta)
+}
+
 func resourceEriRouterV1Delete(d *schema.ResourceData, meta interface{}) error {
 	config := meta.(*Config)
 	client, err := config.eriV1Client(GetRegion(d, config))
@@ -238,26 +271,30 @@ func resourceEriRouterV1Delete(d *schema.ResourceData, meta interface{}) error {
 		return fmt.Errorf("error creating FIC ERI client: %w", err)
 	}
 
-	err = resource.Retry(d.Timeout(schema.TimeoutDelete), func() *resource.RetryError {
+	// The connections and the router are deleted within the same timeout.
+	deadline := time.Now().Add(d.Timeout(schema.TimeoutDelete))
+	if d.Get("force_delete").(bool) {
+		err := deleteRouterConnections(config.StopContext(), client, config.Backoff, d.Id(), deadline)
+		if err != nil {
+			return err
+		}
+	}
+
+	err = retryWithBackoff(config.StopContext(), time.Until(deadline), config.Backoff, func() *resource.RetryError {
 		if err := routers.Delete(client, d.Id()).ExtractErr(); err != nil {
 			var e404 fic.ErrDefault404
 			if errors.As(err, &e404) {
 				return nil
 			}
 
-			var e409 fic.ErrDefault409
-			if errors.As(err, &e409) {
-				return resource.RetryableError(err)
-			}
-
-			return resource.NonRetryableError(err)
+			return checkForRetryableError(err)
 		}
 
 		return nil
 	})
 
 	if err != nil {
-		return fmt.Errorf("error deleting FIC ERI router: %w", err)
+		return formatFICError(err, "Error deleting FIC ERI router")
 	}
 
 	d.SetId("")
@@ -265,6 +302,78 @@ func resourceEriRouterV1Delete(d *schema.ResourceData, meta interface{}) error {
 	return nil
 }
 
+// deleteRouterConnections deletes the connections whose source is the router
+// and waits for each of them to be deleted, all before deadline. A deletion
+// conflicting with an operation in progress is retried with b. The
+// connections are listed only once, so one that keeps coming back or is
+// created meanwhile makes the router deletion fail instead of being chased.
+func deleteRouterConnections(ctx context.Context, client *fic.ServiceClient, b Backoff, routerID string, deadline time.Time) error {
+	conns, err := listConnections(client, "")
+	if err != nil {
+		return fmt.Errorf("error listing connections of FIC ERI router %s: %w", routerID, err)
+	}
+
+	for _, c := range conns {
+		if c.Source.RouterID != routerID {
+			continue
+		}
+
+		log.Printf("[DEBUG] Deleting %s connection %s of router %s", c.ConnectionType, c.ID, routerID)
+		err := retryWithBackoff(ctx, time.Until(deadline), b, func() *resource.RetryError {
+			if _, err := client.Delete(client.ServiceURL(c.path, c.ID), nil); err != nil {
+				var e404 fic.ErrDefault404
+				if errors.As(err, &e404) {
+					return nil
+				}
+
+				return checkForRetryableError(err)
+			}
+
+			return nil
+		})
+		if err != nil {
+			return formatFICError(err, fmt.Sprintf("error deleting connection %s of FIC ERI router %s", c.ID, routerID))
+		}
+
+		stateConf := &resource.StateChangeConf{
+			Pending:    []string{"Processing", "Completed"},
+			Target:     []string{"Deleted"},
+			Refresh:    connectionSummaryStateRefreshFunc(client, c.path, c.ID),
+			Timeout:    time.Until(deadline),
+			MinTimeout: 3 * time.Second,
+		}
+
+		if _, err := waitForState(ctx, stateConf); err != nil {
+			return fmt.Errorf("error waiting for connection %s of FIC ERI router %s to delete: %w", c.ID, routerID, err)
+		}
+	}
+
+	return nil
+}
+
+// connectionSummaryStateRefreshFunc polls a connection of any type, it is
+// reported as Deleted once the API no longer returns it.
+func connectionSummaryStateRefreshFunc(client *fic.ServiceClient, path, connectionID string) resource.StateRefreshFunc {
+	return func() (interface{}, string, error) {
+		var s struct {
+			Connection connectionSummary `json:"connection"`
+		}
+		if _, err := client.Get(client.ServiceURL(path, connectionID), &s, nil); err != nil {
+			var e404 fic.ErrDefault404
+			if errors.As(err, &e404) {
+				return s.Connection, "Deleted", nil
+			}
+			return nil, "", err
+		}
+
+		if s.Connection.OperationStatus == "Error" {
+			return s.Connection, s.Connection.OperationStatus, fmt.Errorf("There was an error retrieving the connection information.")
+		}
+
+		return s.Connection, s.Connection.OperationStatus, nil
+	}
+}
+
 func RouterV1StateRefreshFunc(client *fic.ServiceClient, portID string) resource.StateRefreshFunc {
 	return func() (interface{}, string, error) {
 		v, err := routers.Get(client, portID).Extract()
@@ -2,15 +2,223 @@ package fic
 
 import (
 	"fmt"
+	"net"
+	"net/http"
+	"os"
+	"reflect"
+	"sync"
+	"syscall"
 	"testing"
 
 	"github.com/hashicorp/terraform-plugin-sdk/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/terraform"
 
+	"github.com/nttcom/go-fic"
 	"github.com/nttcom/go-fic/fic/eri/v1/ports"
 	"github.com/nttcom/go-fic/fic/eri/v1/routers"
 )
 
+func TestResourceEriRouterV1DeleteForce(t *testing.T) {
+	for _, force := range []bool{false, true} {
+		var mu sync.Mutex
+		var deletes []string
+		deleted := make(map[string]bool)
+
+		config, closeServer := testMockConfig(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			mu.Lock()
+			defer mu.Unlock()
+
+			w.Header().Set("Content-Type", "application/json")
+			switch {
+			case r.Method == http.MethodDelete:
+				deletes = append(deletes, r.URL.Path)
+				deleted[r.URL.Path] = true
+				w.WriteHeader(http.StatusNoContent)
+			case deleted[r.URL.Path]:
+				w.WriteHeader(http.StatusNotFound)
+			case r.URL.Path == "/v1/router-to-ecl-connections":
+				fmt.Fprint(w, `{"connections": [
+					{"id": "F030123456781", "operationStatus": "Completed", "source": {"routerId": "F020123456789"}},
+					{"id": "F030123456782", "operationStatus": "Completed", "source": {"routerId": "F029876543210"}}
+				]}`)
+			case r.URL.Path == "/v1/port-to-port-connections":
+				fmt.Fprint(w, `{"connections": [
+					{"id": "F030123456783", "operationStatus": "Completed", "source": {"portId": "F010123456789"}}
+				]}`)
+			default:
+				fmt.Fprint(w, `{"connections": []}`)
+			}
+		}))
+
+		d := schema.TestResourceDataRaw(t, resourceEriRouterV1().Schema, map[string]interface{}{
+			"force_delete": force,
+		})
+		d.SetId("F020123456789")
+
+		err := resourceEriRouterV1Delete(d, config)
+		closeServer()
+		if err != nil {
+			t.Fatalf("force_delete %t: unexpected error: %s", force, err)
+		}
+
+		expected := []string{"/v1/routers/F020123456789"}
+		if force {
+			expected = append([]string{"/v1/router-to-ecl-connections/F030123456781"}, expected...)
+		}
+
+		if !reflect.DeepEqual(deletes, expected) {
+			t.Fatalf("force_delete %t: expected deletes %v, got %v", force, expected, deletes)
+		}
+	}
+}
+
+func TestResourceEriRouterV1DeleteForceConflict(t *testing.T) {
+	var mu sync.Mutex
+	var deletes []string
+	deleted := make(map[string]bool)
+
+	config, closeServer := testMockConfig(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		defer mu.Unlock()
+
+		w.Header().Set("Content-Type", "application/json")
+		switch {
+		case r.Method == http.MethodDelete:
+			deletes = append(deletes, r.URL.Path)
+			// The first deletion of the connection conflicts with an
+			// operation in progress.
+			if len(deletes) == 1 {
+				w.WriteHeader(http.StatusConflict)
+				return
+			}
+			deleted[r.URL.Path] = true
+			w.WriteHeader(http.StatusNoContent)
+		case deleted[r.URL.Path]:
+			w.WriteHeader(http.StatusNotFound)
+		case r.URL.Path == "/v1/router-to-ecl-connections":
+			fmt.Fprint(w, `{"connections": [
+				{"id": "F030123456781", "operationStatus": "Completed", "source": {"routerId": "F020123456789"}}
+			]}`)
+		default:
+			fmt.Fprint(w, `{"connections": []}`)
+		}
+	}))
+	defer closeServer()
+
+	d := schema.TestResourceDataRaw(t, resourceEriRouterV1().Schema, map[string]interface{}{
+		"force_delete": true,
+	})
+	d.SetId("F020123456789")
+
+	if err := resourceEriRouterV1Delete(d, config); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	expected := []string{
+		"/v1/router-to-ecl-connections/F030123456781",
+		"/v1/router-to-ecl-connections/F030123456781",
+		"/v1/routers/F020123456789",
+	}
+	if !reflect.DeepEqual(deletes, expected) {
+		t.Fatalf("expected deletes %v, got %v", expected, deletes)
+	}
+}
+
+func TestResourceEriRouterV1DeleteRetry(t *testing.T) {
+	statuses := []int{http.StatusConflict, http.StatusTooManyRequests, http.StatusNoContent}
+	deletes := 0
+
+	config, closeServer := testMockConfig(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodDelete {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+
+		w.WriteHeader(statuses[deletes])
+		deletes++
+	}))
+	defer closeServer()
+
+	d := schema.TestResourceDataRaw(t, resourceEriRouterV1().Schema, map[string]interface{}{})
+	d.SetId("F020123456789")
+
+	if err := resourceEriRouterV1Delete(d, config); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	if deletes != len(statuses) {
+		t.Fatalf("expected %d delete requests, got %d", len(statuses), deletes)
+	}
+
+	if d.Id() != "" {
+		t.Fatalf("expected the ID to be cleared, got %s", d.Id())
+	}
+}
+
+// failingTransport fails the first requests with errs, then sends the
+// following ones with http.DefaultTransport.
+type failingTransport struct {
+	errs []error
+}
+
+func (t *failingTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if len(t.errs) > 0 {
+		err := t.errs[0]
+		t.errs = t.errs[1:]
+		return nil, err
+	}
+
+	return http.DefaultTransport.RoundTrip(req)
+}
+
+func TestResourceEriRouterV1DeleteRetryNetworkError(t *testing.T) {
+	statuses := []int{http.StatusServiceUnavailable, http.StatusNoContent}
+	deletes := 0
+
+	config, closeServer := testMockConfig(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(statuses[deletes])
+		deletes++
+	}))
+	defer closeServer()
+
+	reset := &net.OpError{Op: "read", Net: "tcp", Err: os.NewSyscallError("read", syscall.ECONNRESET)}
+	config.OsClient.HTTPClient = http.Client{Transport: &failingTransport{errs: []error{reset}}}
+
+	d := schema.TestResourceDataRaw(t, resourceEriRouterV1().Schema, map[string]interface{}{})
+	d.SetId("F020123456789")
+
+	if err := resourceEriRouterV1Delete(d, config); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	if deletes != len(statuses) {
+		t.Fatalf("expected %d delete requests to reach the API, got %d", len(statuses), deletes)
+	}
+}
+
+func TestResourceEriRouterV1DeleteRetryTimeout(t *testing.T) {
+	deletes := 0
+	config, closeServer := testMockConfig(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		deletes++
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer closeServer()
+
+	config.OsClient.HTTPClient = http.Client{Transport: &failingTransport{errs: []error{fic.ErrTimeOut{}}}}
+
+	d := schema.TestResourceDataRaw(t, resourceEriRouterV1().Schema, map[string]interface{}{})
+	d.SetId("F020123456789")
+
+	if err := resourceEriRouterV1Delete(d, config); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	if deletes != 1 {
+		t.Fatalf("expected the delete request to be sent again after the timeout, got %d requests", deletes)
+	}
+}
+
 func TestAccEriRouterV1Basic(t *testing.T) {
 	var router routers.Router
 
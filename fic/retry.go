@@ -0,0 +1,237 @@
+package fic
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"math/rand"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+)
+
+// Backoff controls the delay applied between attempts of retryWithBackoff.
+type Backoff struct {
+	// BaseDelay is the delay before the first retry. It is doubled on every
+	// subsequent attempt.
+	BaseDelay time.Duration
+
+	// MaxDelay caps the delay between two attempts.
+	MaxDelay time.Duration
+
+	// MaxAttempts is the number of attempts after which retrying stops.
+	// Zero means the retries are bounded by the timeout only.
+	MaxAttempts int
+
+	// Disabled makes every error non-retryable, so that the first failure
+	// is returned as is.
+	Disabled bool
+}
+
+// delay returns the randomized delay to wait after the given attempt (1-based)
+// using exponential backoff with full jitter.
+func (b Backoff) delay(attempt int) time.Duration {
+	if b.BaseDelay <= 0 {
+		return 0
+	}
+
+	d := b.MaxDelay
+	if attempt < 32 {
+		if exp := b.BaseDelay << uint(attempt-1); exp > 0 && exp < d {
+			d = exp
+		}
+	}
+
+	if d <= 0 {
+		return 0
+	}
+
+	return time.Duration(rand.Int63n(int64(d) + 1))
+}
+
+// retryWithBackoff behaves like resource.Retry, but sleeps for an exponentially
+// growing, jittered delay after every retryable error. The attempt counter is
+// local to a single call, so each resource operation starts from BaseDelay.
+// The number of retries is logged on success and added to the error on
+// failure, so that time spent retrying does not go unnoticed. Retrying stops
+// as soon as ctx is done, e.g. when the apply is interrupted.
+func retryWithBackoff(ctx context.Context, timeout time.Duration, b Backoff, f resource.RetryFunc) error {
+	attempt := 0
+	gaveUp := false
+
+	err := resource.Retry(timeout, func() *resource.RetryError {
+		attempt++
+
+		rerr := f()
+		if rerr == nil || !rerr.Retryable {
+			return rerr
+		}
+
+		if b.Disabled {
+			return resource.NonRetryableError(rerr.Err)
+		}
+
+		if b.MaxAttempts > 0 && attempt >= b.MaxAttempts {
+			gaveUp = true
+			return resource.NonRetryableError(fmt.Errorf("giving up after %d attempts: %w", attempt, rerr.Err))
+		}
+
+		select {
+		case <-time.After(b.delay(attempt)):
+		case <-ctx.Done():
+			return resource.NonRetryableError(fmt.Errorf("operation cancelled while retrying: %w", ctx.Err()))
+		}
+
+		return rerr
+	})
+
+	retries := attempt - 1
+	if retries <= 0 || gaveUp {
+		return err
+	}
+
+	noun := "retries"
+	if retries == 1 {
+		noun = "retry"
+	}
+
+	if err != nil {
+		return fmt.Errorf("failed after %d %s: %w", retries, noun, err)
+	}
+
+	log.Printf("[INFO] Operation succeeded after %d %s", retries, noun)
+	return nil
+}
+
+// waitForState runs conf.WaitForState, but returns as soon as ctx is done,
+// e.g. when the apply is interrupted. The refresh function of conf is wrapped
+// so that the underlying poll stops at its next iteration.
+func waitForState(ctx context.Context, conf *resource.StateChangeConf) (interface{}, error) {
+	refresh := conf.Refresh
+	conf.Refresh = func() (interface{}, string, error) {
+		if err := ctx.Err(); err != nil {
+			return nil, "", err
+		}
+		return refresh()
+	}
+
+	type result struct {
+		v   interface{}
+		err error
+	}
+
+	ch := make(chan result, 1)
+	go func() {
+		v, err := conf.WaitForState()
+		ch <- result{v, err}
+	}()
+
+	select {
+	case r := <-ch:
+		return r.v, r.err
+	case <-ctx.Done():
+		return nil, fmt.Errorf("operation cancelled while waiting for state to become %v: %w", conf.Target, ctx.Err())
+	}
+}
+
+// statusWait describes a wait for a resource to reach one of the Target
+// statuses. Refresh returns the resource and its status; the refresh
+// functions of the resources report a missing resource as "Deleted".
+type statusWait struct {
+	ResourceType string
+	ID           string
+	Refresh      resource.StateRefreshFunc
+	Pending      []string
+	Target       []string
+	Timeout      time.Duration
+
+	// MinTimeout is the smallest delay between two refreshes. It defaults to
+	// statusWaitMinTimeout.
+	MinTimeout time.Duration
+}
+
+// statusWaitDelay is the delay before the first refresh of a status wait,
+// and statusWaitMinTimeout the default smallest delay between refreshes.
+var (
+	statusWaitDelay      = 10 * time.Second
+	statusWaitMinTimeout = 3 * time.Second
+)
+
+// waitForStatus waits for the resource of w to reach one of its Target
+// statuses and returns the last object returned by its refresh function.
+func waitForStatus(ctx context.Context, w statusWait) (interface{}, error) {
+	minTimeout := w.MinTimeout
+	if minTimeout == 0 {
+		minTimeout = statusWaitMinTimeout
+	}
+
+	log.Printf("[DEBUG] Waiting for %s (%s) to become %v", w.ResourceType, w.ID, w.Target)
+
+	return waitForState(ctx, &resource.StateChangeConf{
+		Pending:    w.Pending,
+		Target:     w.Target,
+		Refresh:    w.Refresh,
+		Timeout:    w.Timeout,
+		Delay:      statusWaitDelay,
+		MinTimeout: minTimeout,
+	})
+}
+
+// waitForActive waits for a created resource to reach a target status of w.
+// When wait_for_active is disabled it returns immediately instead, leaving
+// the status to be reconciled by the following reads.
+func (c *Config) waitForActive(w statusWait) (interface{}, error) {
+	if c.SkipWaitForActive {
+		log.Printf("[DEBUG] Not waiting for %s (%s) to become %v, wait_for_active is disabled", w.ResourceType, w.ID, w.Target)
+		return nil, nil
+	}
+
+	return waitForStatus(c.StopContext(), w)
+}
+
+// readAfterCreateTimeout bounds how long readAfterCreate tolerates a missing
+// resource, and readAfterCreateInterval is the delay between its reads.
+var (
+	readAfterCreateTimeout  = 10 * time.Second
+	readAfterCreateInterval = 2 * time.Second
+)
+
+// readAfterCreate runs the Read function of a resource that was just
+// created. FIC may answer 404 for a connection right after creating it, and
+// Read then clears the ID as if the connection had been deleted. The ID is
+// restored and the read repeated until readAfterCreateTimeout has passed.
+// The ID is kept when the read gives up, so that the created connection is
+// saved as tainted instead of being dropped from the state while it still
+// exists in FIC. Reads in later operations still drop a missing resource at
+// once. After a successful read, created_at is looked up with readCreatedAt.
+func readAfterCreate(d *schema.ResourceData, meta interface{}, read schema.ReadFunc) error {
+	id := d.Id()
+	ctx := meta.(*Config).StopContext()
+	deadline := time.Now().Add(readAfterCreateTimeout)
+
+	for {
+		if err := read(d, meta); err != nil {
+			return err
+		}
+
+		if d.Id() != "" {
+			readCreatedAt(d, meta)
+			return nil
+		}
+
+		if time.Now().After(deadline) {
+			d.SetId(id)
+			return fmt.Errorf("%s was not found within %s after it was created", id, readAfterCreateTimeout)
+		}
+
+		log.Printf("[DEBUG] %s not found after it was created, reading it again", id)
+		d.SetId(id)
+
+		select {
+		case <-time.After(readAfterCreateInterval):
+		case <-ctx.Done():
+			return fmt.Errorf("reading %s after it was created: %w", id, ctx.Err())
+		}
+	}
+}
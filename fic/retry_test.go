@@ -0,0 +1,338 @@
+package fic
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"log"
+	"os"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"github.com/nttcom/go-fic"
+)
+
+func fakeErrorSequence(errs []error, calls *int) resource.RetryFunc {
+	return func() *resource.RetryError {
+		err := errs[*calls]
+		*calls++
+		if err == nil {
+			return nil
+		}
+		return checkForRetryableError(err)
+	}
+}
+
+func TestRetryWithBackoff(t *testing.T) {
+	b := Backoff{BaseDelay: time.Millisecond, MaxDelay: 5 * time.Millisecond}
+	errs := []error{
+		fic.ErrDefault500{},
+		fic.ErrUnexpectedResponseCode{Actual: 409},
+		nil,
+	}
+
+	calls := 0
+	if err := retryWithBackoff(context.Background(), time.Minute, b, fakeErrorSequence(errs, &calls)); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	if calls != len(errs) {
+		t.Fatalf("expected %d attempts, got %d", len(errs), calls)
+	}
+
+	// The attempt counter must not carry over to a new operation.
+	b.MaxAttempts = 2
+	calls = 0
+	if err := retryWithBackoff(context.Background(), time.Minute, b, fakeErrorSequence(errs[1:], &calls)); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+}
+
+func TestRetryWithBackoffReportsRetries(t *testing.T) {
+	var buf bytes.Buffer
+	log.SetOutput(&buf)
+	defer log.SetOutput(os.Stderr)
+
+	b := Backoff{BaseDelay: time.Millisecond, MaxDelay: 5 * time.Millisecond}
+	errs := []error{
+		fic.ErrDefault500{},
+		fic.ErrUnexpectedResponseCode{Actual: 409},
+		nil,
+	}
+
+	calls := 0
+	if err := retryWithBackoff(context.Background(), time.Minute, b, fakeErrorSequence(errs, &calls)); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	if !strings.Contains(buf.String(), "[INFO] Operation succeeded after 2 retries") {
+		t.Fatalf("expected the retries to be logged, got %q", buf.String())
+	}
+
+	errs = []error{
+		fic.ErrUnexpectedResponseCode{Actual: 503},
+		fic.ErrUnexpectedResponseCode{Actual: 400},
+	}
+
+	calls = 0
+	err := retryWithBackoff(context.Background(), time.Minute, b, fakeErrorSequence(errs, &calls))
+	if err == nil || !strings.HasPrefix(err.Error(), "failed after 1 retry: ") {
+		t.Fatalf("expected the error to report 1 retry, got %v", err)
+	}
+
+	var e fic.ErrUnexpectedResponseCode
+	if !errors.As(err, &e) || e.Actual != 400 {
+		t.Fatalf("expected wrapped 400 error, got %s", err)
+	}
+}
+
+func TestRetryWithBackoffMaxAttempts(t *testing.T) {
+	b := Backoff{BaseDelay: time.Millisecond, MaxDelay: 5 * time.Millisecond, MaxAttempts: 2}
+	errs := []error{
+		fic.ErrUnexpectedResponseCode{Actual: 503},
+		fic.ErrUnexpectedResponseCode{Actual: 503},
+		nil,
+	}
+
+	calls := 0
+	err := retryWithBackoff(context.Background(), time.Minute, b, fakeErrorSequence(errs, &calls))
+	if err == nil {
+		t.Fatal("expected an error after exhausting attempts")
+	}
+
+	var e fic.ErrUnexpectedResponseCode
+	if !errors.As(err, &e) || e.Actual != 503 {
+		t.Fatalf("expected wrapped 503 error, got %s", err)
+	}
+
+	if calls != 2 {
+		t.Fatalf("expected 2 attempts, got %d", calls)
+	}
+}
+
+func TestRetryWithBackoffNonRetryable(t *testing.T) {
+	b := Backoff{BaseDelay: time.Millisecond, MaxDelay: 5 * time.Millisecond}
+	errs := []error{
+		fic.ErrUnexpectedResponseCode{Actual: 400},
+		nil,
+	}
+
+	calls := 0
+	if err := retryWithBackoff(context.Background(), time.Minute, b, fakeErrorSequence(errs, &calls)); err == nil {
+		t.Fatal("expected a non-retryable error")
+	}
+
+	if calls != 1 {
+		t.Fatalf("expected 1 attempt, got %d", calls)
+	}
+}
+
+func TestRetryWithBackoffDisabled(t *testing.T) {
+	b := Backoff{BaseDelay: time.Millisecond, MaxDelay: 5 * time.Millisecond, Disabled: true}
+	errs := []error{
+		fic.ErrUnexpectedResponseCode{Actual: 503},
+		nil,
+	}
+
+	calls := 0
+	err := retryWithBackoff(context.Background(), time.Minute, b, fakeErrorSequence(errs, &calls))
+
+	var e fic.ErrUnexpectedResponseCode
+	if !errors.As(err, &e) || e.Actual != 503 {
+		t.Fatalf("expected the 503 error, got %v", err)
+	}
+
+	if calls != 1 {
+		t.Fatalf("expected 1 attempt, got %d", calls)
+	}
+}
+
+func TestRetryWithBackoffCancelled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	b := Backoff{BaseDelay: time.Hour, MaxDelay: time.Hour}
+
+	go func() {
+		time.Sleep(50 * time.Millisecond)
+		cancel()
+	}()
+
+	// Every attempt is retryable, so only the cancellation ends the retries.
+	f := func() *resource.RetryError {
+		return checkForRetryableError(fic.ErrDefault500{})
+	}
+
+	start := time.Now()
+	err := retryWithBackoff(ctx, time.Hour, b, f)
+	if !errors.Is(err, context.Canceled) {
+		t.Fatalf("expected a context.Canceled error, got %v", err)
+	}
+
+	if elapsed := time.Since(start); elapsed > 5*time.Second {
+		t.Fatalf("expected a prompt return after cancellation, took %s", elapsed)
+	}
+}
+
+func TestBackoffDelay(t *testing.T) {
+	b := Backoff{BaseDelay: time.Second, MaxDelay: 4 * time.Second}
+
+	for attempt := 1; attempt <= 64; attempt++ {
+		if d := b.delay(attempt); d < 0 || d > b.MaxDelay {
+			t.Fatalf("attempt %d: delay %s out of range", attempt, d)
+		}
+	}
+
+	if d := (Backoff{}).delay(1); d != 0 {
+		t.Fatalf("expected no delay for zero backoff, got %s", d)
+	}
+}
+
+func TestWaitForStateCancelled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+
+	conf := &resource.StateChangeConf{
+		Pending: []string{"Processing"},
+		Target:  []string{"Completed"},
+		Refresh: func() (interface{}, string, error) {
+			return struct{}{}, "Processing", nil
+		},
+		Timeout:    time.Hour,
+		MinTimeout: 10 * time.Millisecond,
+	}
+
+	go func() {
+		time.Sleep(50 * time.Millisecond)
+		cancel()
+	}()
+
+	start := time.Now()
+	_, err := waitForState(ctx, conf)
+	if !errors.Is(err, context.Canceled) {
+		t.Fatalf("expected a context.Canceled error, got %v", err)
+	}
+
+	if elapsed := time.Since(start); elapsed > 5*time.Second {
+		t.Fatalf("expected a prompt return after cancellation, took %s", elapsed)
+	}
+}
+
+func TestWaitForStateCompleted(t *testing.T) {
+	states := []string{"Processing", "Completed"}
+	calls := 0
+
+	conf := &resource.StateChangeConf{
+		Pending: []string{"Processing"},
+		Target:  []string{"Completed"},
+		Refresh: func() (interface{}, string, error) {
+			state := states[calls]
+			calls++
+			return struct{}{}, state, nil
+		},
+		Timeout:    time.Minute,
+		MinTimeout: 10 * time.Millisecond,
+	}
+
+	if _, err := waitForState(context.Background(), conf); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+}
+
+func TestWaitForStatus(t *testing.T) {
+	defer func(delay time.Duration) { statusWaitDelay = delay }(statusWaitDelay)
+	statusWaitDelay = 0
+
+	states := []string{"Processing", "Processing", "Completed"}
+	calls := 0
+
+	v, err := waitForStatus(context.Background(), statusWait{
+		ResourceType: "connection",
+		ID:           "F030123456789",
+		Refresh: func() (interface{}, string, error) {
+			state := states[calls]
+			calls++
+			return calls, state, nil
+		},
+		Pending:    []string{"Processing"},
+		Target:     []string{"Completed"},
+		Timeout:    time.Minute,
+		MinTimeout: 10 * time.Millisecond,
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	if v != 3 {
+		t.Fatalf("expected the object of the last refresh, got %v", v)
+	}
+}
+
+func TestWaitForStatusError(t *testing.T) {
+	defer func(delay time.Duration) { statusWaitDelay = delay }(statusWaitDelay)
+	statusWaitDelay = 0
+
+	states := []string{"Processing", "Error"}
+	calls := 0
+
+	_, err := waitForStatus(context.Background(), statusWait{
+		ResourceType: "connection",
+		ID:           "F030123456789",
+		Refresh: func() (interface{}, string, error) {
+			state := states[calls]
+			calls++
+			if state == "Error" {
+				return struct{}{}, state, errors.New("operation failed")
+			}
+			return struct{}{}, state, nil
+		},
+		Pending:    []string{"Processing"},
+		Target:     []string{"Completed"},
+		Timeout:    time.Minute,
+		MinTimeout: 10 * time.Millisecond,
+	})
+	if err == nil || calls != 2 {
+		t.Fatalf("expected the wait to stop at the error, got %v after %d refreshes", err, calls)
+	}
+}
+
+func TestWaitForActiveSkipped(t *testing.T) {
+	config := &Config{SkipWaitForActive: true}
+
+	v, err := config.waitForActive(statusWait{
+		ResourceType: "connection",
+		ID:           "F030123456789",
+		Refresh: func() (interface{}, string, error) {
+			t.Fatal("unexpected refresh")
+			return nil, "", nil
+		},
+		Pending: []string{"Processing"},
+		Target:  []string{"Completed"},
+		Timeout: time.Minute,
+	})
+	if err != nil || v != nil {
+		t.Fatalf("expected no wait, got %v, %v", v, err)
+	}
+}
+
+func TestReadAfterCreateTimeout(t *testing.T) {
+	defer func(timeout, interval time.Duration) {
+		readAfterCreateTimeout, readAfterCreateInterval = timeout, interval
+	}(readAfterCreateTimeout, readAfterCreateInterval)
+	readAfterCreateTimeout, readAfterCreateInterval = 10*time.Millisecond, time.Millisecond
+
+	d := (&schema.Resource{Schema: map[string]*schema.Schema{}}).TestResourceData()
+	d.SetId("F030123456789")
+
+	err := readAfterCreate(d, &Config{}, func(d *schema.ResourceData, meta interface{}) error {
+		d.SetId("")
+		return nil
+	})
+	if err == nil || !strings.Contains(err.Error(), "F030123456789 was not found") {
+		t.Fatalf("expected a not found error, got %v", err)
+	}
+
+	if d.Id() != "F030123456789" {
+		t.Fatalf("expected the ID to be kept, got %q", d.Id())
+	}
+}
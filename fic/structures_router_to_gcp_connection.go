@@ -5,7 +5,10 @@ import (
 )
 
 func expandSource(in []interface{}) connections.Source {
-	m := in[0].(map[string]interface{})
+	m, ok := singleBlock(in)
+	if !ok {
+		return connections.Source{}
+	}
 	primaryMEDOut := m["primary_med_out"].(int)
 
 	return connections.Source{
@@ -26,7 +29,10 @@ func expandSource(in []interface{}) connections.Source {
 }
 
 func expandRouteFilter(in []interface{}) connections.RouteFilter {
-	m := in[0].(map[string]interface{})
+	m, ok := singleBlock(in)
+	if !ok {
+		return connections.RouteFilter{}
+	}
 
 	return connections.RouteFilter{
 		In:  m["in"].(string),
@@ -35,7 +41,10 @@ func expandRouteFilter(in []interface{}) connections.RouteFilter {
 }
 
 func expandDestination(in []interface{}) connections.Destination {
-	m := in[0].(map[string]interface{})
+	m, ok := singleBlock(in)
+	if !ok {
+		return connections.Destination{}
+	}
 
 	return connections.Destination{
 		QosType:   "guarantee",
@@ -45,7 +54,10 @@ func expandDestination(in []interface{}) connections.Destination {
 }
 
 func expandInterconnect(in []interface{}) connections.DestinationHAInfo {
-	m := in[0].(map[string]interface{})
+	m, ok := singleBlock(in)
+	if !ok {
+		return connections.DestinationHAInfo{}
+	}
 
 	return connections.DestinationHAInfo{
 		Interconnect: m["interconnect"].(string),
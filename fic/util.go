@@ -1,14 +1,22 @@
 package fic
 
 import (
+	"context"
+	"encoding/json"
 	"errors"
 	"fmt"
+	"log"
+	"net"
 	"net/http"
 	"sort"
+	"strconv"
 	"strings"
+	"syscall"
 	"time"
 
 	"github.com/nttcom/go-fic"
+	"github.com/nttcom/go-fic/fic/eri/v1/operations"
+	"github.com/nttcom/go-fic/pagination"
 
 	"github.com/hashicorp/terraform-plugin-sdk/helper/resource"
 	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
@@ -23,7 +31,10 @@ func BuildRequest(opts interface{}, parent string) (map[string]interface{}, erro
 		return nil, err
 	}
 
-	b = AddValueSpecs(b)
+	b, err = AddValueSpecs(b)
+	if err != nil {
+		return nil, err
+	}
 
 	return map[string]interface{}{parent: b}, nil
 }
@@ -31,19 +42,33 @@ func BuildRequest(opts interface{}, parent string) (map[string]interface{}, erro
 // CheckDeleted checks the error to see if it's a 404 (Not Found) and, if so,
 // sets the resource ID to the empty string instead of throwing an error.
 func CheckDeleted(d *schema.ResourceData, err error, msg string) error {
+	_, err = CheckDeletedWithFlag(d, err, msg)
+	return err
+}
+
+// CheckDeletedWithFlag behaves like CheckDeleted, but also reports whether
+// the resource was found to be deleted.
+func CheckDeletedWithFlag(d *schema.ResourceData, err error, msg string) (bool, error) {
 	var e fic.ErrDefault404
 	if errors.As(err, &e) {
 		d.SetId("")
-		return nil
+		return true, nil
 	}
 
-	return fmt.Errorf("%s: %w", msg, err)
+	return false, formatFICError(err, msg)
 }
 
 // GetRegion returns the region that was specified in the resource. If a
-// region was not set, the provider-level region is checked. The provider-level
-// region can either be set by the region argument or by OS_REGION_NAME.
-func GetRegion(d *schema.ResourceData, config *Config) string {
+// region was not set, the provider-level region argument is used. The provider
+// argument itself defaults to the FIC_REGION environment variable and, failing
+// that, to OS_REGION_NAME, so that a region set in the provider block always
+// wins over the environment.
+//
+// d is usually a *schema.ResourceData, but a *schema.ResourceDiff can be
+// passed from a CustomizeDiff function.
+func GetRegion(d interface {
+	GetOk(string) (interface{}, bool)
+}, config *Config) string {
 	if v, ok := d.GetOk("region"); ok {
 		return v.(string)
 	}
@@ -51,26 +76,70 @@ func GetRegion(d *schema.ResourceData, config *Config) string {
 	return config.Region
 }
 
+// GetBoolPtr returns a pointer to the value of a boolean attribute, or nil if
+// the attribute is absent. Unlike GetOk, an explicit false is reported as set.
+// This relies on GetOkExists, which can't tell an unset attribute from one
+// filled in by a schema Default, so attributes with a Default never return nil.
+func GetBoolPtr(d *schema.ResourceData, key string) *bool {
+	v, ok := d.GetOkExists(key)
+	if !ok {
+		return nil
+	}
+
+	b := v.(bool)
+	return &b
+}
+
 // AddValueSpecs expands the 'value_specs' object and removes 'value_specs'
 // from the request body.
-func AddValueSpecs(body map[string]interface{}) map[string]interface{} {
-	if body["value_specs"] != nil {
-		for k, v := range body["value_specs"].(map[string]interface{}) {
-			body[k] = v
+// Values are copied as-is, so non-string scalars keep their type.
+// value_specs is an escape hatch for API fields the provider doesn't model
+// yet, so it may only add keys: an error naming the key is returned if it
+// collides with a field already in the body.
+func AddValueSpecs(body map[string]interface{}) (map[string]interface{}, error) {
+	specs := make(map[string]interface{})
+	switch v := body["value_specs"].(type) {
+	case map[string]interface{}:
+		specs = v
+	case map[string]string:
+		for k, s := range v {
+			specs[k] = s
 		}
-		delete(body, "value_specs")
 	}
+	delete(body, "value_specs")
+
+	keys := make([]string, 0, len(specs))
+	for k := range specs {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	for _, k := range keys {
+		if _, ok := body[k]; ok {
+			return nil, fmt.Errorf("value_specs.%s conflicts with a field that is already set, use the argument instead", k)
+		}
+		body[k] = specs[k]
+	}
+
+	return body, nil
+}
 
-	return body
+// MapValueSpecs converts ResourceData into a map. An error naming the
+// offending key is returned if a value is not a string.
+func MapValueSpecs(d *schema.ResourceData) (map[string]string, error) {
+	return mapValueSpecs(d.Get("value_specs").(map[string]interface{}))
 }
 
-// MapValueSpecs converts ResourceData into a map
-func MapValueSpecs(d *schema.ResourceData) map[string]string {
+func mapValueSpecs(specs map[string]interface{}) (map[string]string, error) {
 	m := make(map[string]string)
-	for key, val := range d.Get("value_specs").(map[string]interface{}) {
-		m[key] = val.(string)
+	for key, val := range specs {
+		v, ok := val.(string)
+		if !ok {
+			return nil, fmt.Errorf("value_specs.%s must be a string, got %T", key, val)
+		}
+		m[key] = v
 	}
-	return m
+	return m, nil
 }
 
 // List of headers that need to be redacted
@@ -79,11 +148,36 @@ var REDACT_HEADERS = []string{"x-auth-token", "x-auth-key", "x-service-token",
 	"x-container-meta-temp-url-key", "x-container-meta-temp-url-key-2", "set-cookie",
 	"x-subject-token"}
 
+// AddRedactHeaders appends the given header names to REDACT_HEADERS.
+// Names are stored in lowercase and duplicates are ignored.
+func AddRedactHeaders(headers ...string) {
+	for _, h := range headers {
+		h = strings.ToLower(strings.TrimSpace(h))
+		if h == "" || com.IsSliceContainsStr(REDACT_HEADERS, h) {
+			continue
+		}
+		REDACT_HEADERS = append(REDACT_HEADERS, h)
+	}
+}
+
+// isRedactHeader reports whether the header name is listed in REDACT_HEADERS.
+// Both sides are lowercased, so canonical HTTP casing such as X-Auth-Token
+// is matched as well.
+func isRedactHeader(name string) bool {
+	name = strings.ToLower(name)
+	for _, h := range REDACT_HEADERS {
+		if strings.ToLower(h) == name {
+			return true
+		}
+	}
+	return false
+}
+
 // RedactHeaders processes a headers object, returning a redacted list
 func RedactHeaders(headers http.Header) (processedHeaders []string) {
 	for name, header := range headers {
 		for _, v := range header {
-			if com.IsSliceContainsStr(REDACT_HEADERS, name) {
+			if isRedactHeader(name) {
 				processedHeaders = append(processedHeaders, fmt.Sprintf("%v: %v", name, "***"))
 			} else {
 				processedHeaders = append(processedHeaders, fmt.Sprintf("%v: %v", name, v))
@@ -102,12 +196,16 @@ func FormatHeaders(headers http.Header, seperator string) string {
 }
 
 func checkForRetryableError(err error) *resource.RetryError {
+	if isTransientNetworkError(err) || isFICTimeout(err) {
+		return resource.RetryableError(err)
+	}
+
 	switch errCode := err.(type) {
-	case fic.ErrDefault500:
+	case fic.ErrDefault409, fic.ErrDefault429, fic.ErrDefault500, fic.ErrDefault503:
 		return resource.RetryableError(err)
 	case fic.ErrUnexpectedResponseCode:
 		switch errCode.Actual {
-		case 409, 503:
+		case 409, 429, 503:
 			return resource.RetryableError(err)
 		default:
 			return resource.NonRetryableError(err)
@@ -117,13 +215,643 @@ func checkForRetryableError(err error) *resource.RetryError {
 	}
 }
 
+// isTransientNetworkError reports whether err is a network failure that is
+// likely to succeed when retried: a timeout, a refused or reset connection,
+// or a temporary DNS failure. A cancelled context is never retried.
+func isTransientNetworkError(err error) bool {
+	if err == nil || errors.Is(err, context.Canceled) {
+		return false
+	}
+
+	var dnsErr *net.DNSError
+	if errors.As(err, &dnsErr) {
+		return dnsErr.IsTemporary || dnsErr.IsTimeout
+	}
+
+	if errors.Is(err, syscall.ECONNREFUSED) || errors.Is(err, syscall.ECONNRESET) {
+		return true
+	}
+
+	var netErr net.Error
+	return errors.As(err, &netErr) && netErr.Timeout()
+}
+
+// isFICTimeout reports whether err is or wraps a go-fic timeout error. An
+// error returned by the HTTP client reaches the caller wrapped in a
+// *url.Error.
+func isFICTimeout(err error) bool {
+	var e fic.ErrTimeOut
+	var pe *fic.ErrTimeOut
+	return errors.As(err, &e) || errors.As(err, &pe)
+}
+
+// unexpectedResponseCode returns the response details carried by a go-fic
+// HTTP error, including the ErrDefaultXXX types that embed them.
+func unexpectedResponseCode(err error) (fic.ErrUnexpectedResponseCode, bool) {
+	switch e := err.(type) {
+	case fic.ErrUnexpectedResponseCode:
+		return e, true
+	case *fic.ErrUnexpectedResponseCode:
+		return *e, true
+	case fic.ErrDefault400:
+		return e.ErrUnexpectedResponseCode, true
+	case fic.ErrDefault401:
+		return e.ErrUnexpectedResponseCode, true
+	case fic.ErrDefault403:
+		return e.ErrUnexpectedResponseCode, true
+	case fic.ErrDefault404:
+		return e.ErrUnexpectedResponseCode, true
+	case fic.ErrDefault405:
+		return e.ErrUnexpectedResponseCode, true
+	case fic.ErrDefault408:
+		return e.ErrUnexpectedResponseCode, true
+	case fic.ErrDefault409:
+		return e.ErrUnexpectedResponseCode, true
+	case fic.ErrDefault429:
+		return e.ErrUnexpectedResponseCode, true
+	case fic.ErrDefault500:
+		return e.ErrUnexpectedResponseCode, true
+	case fic.ErrDefault503:
+		return e.ErrUnexpectedResponseCode, true
+	}
+
+	if u := errors.Unwrap(err); u != nil {
+		return unexpectedResponseCode(u)
+	}
+
+	return fic.ErrUnexpectedResponseCode{}, false
+}
+
+// operationID looks for the FIC operation ID in an API response body. It is
+// either a top-level field or nested in the resource object of the response.
+func operationID(body []byte) string {
+	var v map[string]interface{}
+	if err := json.Unmarshal(body, &v); err != nil {
+		return ""
+	}
+
+	if id, ok := v["operationId"].(string); ok {
+		return id
+	}
+
+	for _, nested := range v {
+		if m, ok := nested.(map[string]interface{}); ok {
+			if id, ok := m["operationId"].(string); ok {
+				return id
+			}
+		}
+	}
+
+	return ""
+}
+
+// apiError is one entry of the errors array that FIC returns when a request
+// fails validation on several fields at once.
+type apiError struct {
+	Field   string `json:"field"`
+	Code    string `json:"code"`
+	Message string `json:"message"`
+}
+
+// apiErrors extracts the sub-errors of an API response body. The errors
+// array is either a top-level field or nested in an error object.
+func apiErrors(body []byte) []apiError {
+	var v struct {
+		Errors []apiError `json:"errors"`
+		Error  struct {
+			Errors []apiError `json:"errors"`
+		} `json:"error"`
+	}
+	if err := json.Unmarshal(body, &v); err != nil {
+		return nil
+	}
+
+	if len(v.Errors) > 0 {
+		return v.Errors
+	}
+	return v.Error.Errors
+}
+
+// formatAPIErrors formats sub-errors one per line, as "field (code): message"
+// with whichever parts the API returned.
+func formatAPIErrors(errs []apiError) string {
+	var b strings.Builder
+	for _, e := range errs {
+		b.WriteString("\n  - ")
+		if e.Field != "" {
+			b.WriteString(e.Field)
+			b.WriteString(" ")
+		}
+		if e.Code != "" {
+			fmt.Fprintf(&b, "(%s) ", e.Code)
+		}
+		b.WriteString(e.Message)
+	}
+	return strings.TrimRight(b.String(), " ")
+}
+
+// formatFICError wraps an error returned by go-fic with a description of the
+// failed operation. The FIC operation ID is appended when the API returned
+// one, so that it can be quoted in support requests, and each sub-error of a
+// multi-error response is listed on its own line.
+func formatFICError(err error, operation string) error {
+	e, ok := unexpectedResponseCode(err)
+	if !ok {
+		return fmt.Errorf("%s: %w", operation, err)
+	}
+
+	if id := operationID(e.Body); id != "" {
+		operation = fmt.Sprintf("%s (operation ID: %s)", operation, id)
+	}
+
+	if errs := apiErrors(e.Body); len(errs) > 0 {
+		return fmt.Errorf("%s: %w%s", operation, err, formatAPIErrors(errs))
+	}
+
+	return fmt.Errorf("%s: %w", operation, err)
+}
+
+// flattenASPathPrepend converts an AS path prepend value returned by the API
+// into the string form used in the schema. A missing value is "OFF".
+func flattenASPathPrepend(v *interface{}) string {
+	if v == nil || *v == nil {
+		return "OFF"
+	}
+
+	switch n := (*v).(type) {
+	case float64:
+		return strconv.Itoa(int(n))
+	case int:
+		return strconv.Itoa(n)
+	case string:
+		return n
+	default:
+		return fmt.Sprintf("%v", n)
+	}
+}
+
+// suppressASPathPrependOffDiff treats an empty AS path prepend the same as
+// "OFF", as both disable prepending.
+func suppressASPathPrependOffDiff(k, old, new string, d *schema.ResourceData) bool {
+	normalize := func(v string) string {
+		if v == "" {
+			return "OFF"
+		}
+		return v
+	}
+
+	return normalize(old) == normalize(new)
+}
+
+// canonicalCIDR returns the canonical form of a CIDR, with the host bits
+// cleared and leading zeros removed from the octets of an IPv4 address, so
+// that "010.0.0.1/8" becomes "10.0.0.0/8".
+func canonicalCIDR(v string) (string, error) {
+	parts := strings.SplitN(strings.TrimSpace(v), "/", 2)
+	if len(parts) != 2 {
+		return "", fmt.Errorf("%q is not in CIDR notation", v)
+	}
+
+	if octets := strings.Split(parts[0], "."); len(octets) == 4 {
+		for i, o := range octets {
+			n, err := strconv.Atoi(o)
+			if err != nil || n < 0 || n > 255 {
+				return "", fmt.Errorf("%q is not a valid IPv4 address", parts[0])
+			}
+			octets[i] = strconv.Itoa(n)
+		}
+		parts[0] = strings.Join(octets, ".")
+	}
+
+	_, ipnet, err := net.ParseCIDR(parts[0] + "/" + parts[1])
+	if err != nil {
+		return "", fmt.Errorf("%q is not a valid CIDR: %s", v, err)
+	}
+
+	return ipnet.String(), nil
+}
+
+// suppressEquivalentCIDRDiff suppresses diffs between CIDRs that denote the
+// same network, such as "10.0.0.1/24" and "10.0.0.0/24".
+func suppressEquivalentCIDRDiff(k, old, new string, d *schema.ResourceData) bool {
+	oldCIDR, err := canonicalCIDR(old)
+	if err != nil {
+		return false
+	}
+
+	newCIDR, err := canonicalCIDR(new)
+	if err != nil {
+		return false
+	}
+
+	return oldCIDR == newCIDR
+}
+
+// parseBandwidth converts a bandwidth such as "10M", "1G" or "100Mbps" into
+// megabits per second. Whitespace and the unit casing are ignored.
+func parseBandwidth(v string) (int, bool) {
+	v = strings.ToUpper(strings.Join(strings.Fields(v), ""))
+	v = strings.TrimSuffix(v, "BPS")
+
+	multiplier := 1
+	switch {
+	case strings.HasSuffix(v, "G"):
+		multiplier = 1000
+		v = strings.TrimSuffix(v, "G")
+	case strings.HasSuffix(v, "M"):
+		v = strings.TrimSuffix(v, "M")
+	default:
+		return 0, false
+	}
+
+	n, err := strconv.Atoi(v)
+	if err != nil || n < 0 {
+		return 0, false
+	}
+
+	return n * multiplier, true
+}
+
+// suppressEquivalentBandwidthDiff suppresses diffs between bandwidths that
+// only differ in notation, e.g. "1G", "1000M" and "1gbps".
+func suppressEquivalentBandwidthDiff(k, old, new string, d *schema.ResourceData) bool {
+	oldBandwidth, ok := parseBandwidth(old)
+	if !ok {
+		return false
+	}
+
+	newBandwidth, ok := parseBandwidth(new)
+	if !ok {
+		return false
+	}
+
+	return oldBandwidth == newBandwidth
+}
+
+// getOptionalBlock returns the content of the single nested block key of d.
+// It returns false instead of panicking when the block is absent or empty.
+func getOptionalBlock(d *schema.ResourceData, key string) (map[string]interface{}, bool) {
+	in, ok := d.Get(key).([]interface{})
+	if !ok {
+		return nil, false
+	}
+	return singleBlock(in)
+}
+
+// singleBlock returns the content of a nested block with at most one item,
+// or false when the block has no item or its item is empty.
+func singleBlock(in []interface{}) (map[string]interface{}, bool) {
+	if len(in) == 0 || in[0] == nil {
+		return nil, false
+	}
+
+	m, ok := in[0].(map[string]interface{})
+	return m, ok
+}
+
+// suppressCaseInsensitiveDiff suppresses diffs between values that only
+// differ in casing, e.g. an area or location returned as "JPEAST" for
+// "jpeast".
+func suppressCaseInsensitiveDiff(k, old, new string, d *schema.ResourceData) bool {
+	return strings.EqualFold(old, new)
+}
+
+// warnOnOperationError logs a warning if the last operation on a connection
+// failed. FIC keeps such a connection in the Error state until it is
+// deleted, so it is usually not working even though it is still in state.
+func warnOnOperationError(d *schema.ResourceData, status string) {
+	if status != "Error" {
+		return
+	}
+
+	if d.Get("recreate_on_error").(bool) {
+		log.Printf("[WARN] Connection %s is in Error state, it will be replaced on the next apply", d.Id())
+		return
+	}
+
+	log.Printf("[WARN] Connection %s is in Error state, set recreate_on_error to replace it on the next apply", d.Id())
+}
+
+// customizeDiffRecreateOnError plans the replacement of an existing
+// connection in the Error state if recreate_on_error is set.
+func customizeDiffRecreateOnError(d *schema.ResourceDiff, meta interface{}) error {
+	if d.Id() == "" || !d.Get("recreate_on_error").(bool) {
+		return nil
+	}
+
+	if d.Get("operation_status").(string) != "Error" {
+		return nil
+	}
+
+	if err := d.SetNewComputed("operation_status"); err != nil {
+		return err
+	}
+
+	return d.ForceNew("operation_status")
+}
+
+// warnReplacedASNs logs a warning for each BGP ASN of keys that changes on
+// an existing connection. FIC cannot change the ASN of a BGP peer in place,
+// so the ASNs are ForceNew. The SDK cannot attach a warning to the plan, so
+// the log says why the connection is replaced.
+func warnReplacedASNs(d *schema.ResourceDiff, keys ...string) {
+	if d.Id() == "" {
+		return
+	}
+
+	for _, k := range keys {
+		if !d.HasChange(k) {
+			continue
+		}
+
+		old, new := d.GetChange(k)
+		log.Printf("[WARN] Connection %s will be replaced: %s changes from %v to %v, "+
+			"and FIC cannot change the BGP ASN of an existing connection", d.Id(), k, old, new)
+	}
+}
+
+// adoptExistingConnection takes over a connection of the given type that
+// already has the configured name, if adopt_existing is set, so that a
+// Create repeated after losing state does not create a duplicate. The
+// connection is read into d with the Read function of r and adopted only if
+// its configured string and integer attributes match, compared with their
+// DiffSuppressFunc if any. The attributes of nested blocks, such as the
+// router, port and VLAN of each leg, are compared as well as the length of
+// every list. Sensitive attributes are not compared. It reports whether a
+// connection was adopted.
+func adoptExistingConnection(d *schema.ResourceData, meta interface{}, connectionType string, r *schema.Resource) (bool, error) {
+	if !d.Get("adopt_existing").(bool) {
+		return false, nil
+	}
+
+	config := meta.(*Config)
+	client, err := config.eriV1Client(GetRegion(d, config))
+	if err != nil {
+		return false, fmt.Errorf("Error creating FIC ERI client: %s", err)
+	}
+
+	name := d.Get("name").(string)
+	conns, err := listConnections(client, connectionType)
+	if err != nil {
+		return false, err
+	}
+
+	var ids []string
+	for _, c := range conns {
+		if c.Name == name {
+			ids = append(ids, c.ID)
+		}
+	}
+
+	switch len(ids) {
+	case 0:
+		return false, nil
+	case 1:
+	default:
+		return false, fmt.Errorf("unable to adopt connection %q, it is the name of %d connections: %s",
+			name, len(ids), strings.Join(ids, ", "))
+	}
+
+	compared := make(map[string]*schema.Schema)
+	adoptionKeys(d, "", r.Schema, compared)
+
+	keys := make([]string, 0, len(compared))
+	for k := range compared {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	configured := make(map[string]interface{}, len(keys))
+	for _, k := range keys {
+		configured[k] = d.Get(k)
+	}
+
+	log.Printf("[DEBUG] Adopting existing connection %s named %q", ids[0], name)
+	d.SetId(ids[0])
+	if err := r.Read(d, meta); err != nil {
+		d.SetId("")
+		return false, err
+	}
+
+	if d.Id() == "" {
+		return false, fmt.Errorf("connection %s to adopt was deleted", ids[0])
+	}
+
+	var conflicts []string
+	for _, k := range keys {
+		want, got := configured[k], d.Get(k)
+		if want == got {
+			continue
+		}
+
+		if f := compared[k].DiffSuppressFunc; f != nil && f(k, fmt.Sprint(got), fmt.Sprint(want), d) {
+			continue
+		}
+
+		conflicts = append(conflicts, fmt.Sprintf("%s is %v, configured %v", k, got, want))
+	}
+
+	if len(conflicts) > 0 {
+		d.SetId("")
+		return false, fmt.Errorf("unable to adopt connection %s named %q: %s", ids[0], name, strings.Join(conflicts, ", "))
+	}
+
+	return true, nil
+}
+
+// adoptionKeys adds to keys the configured, non-sensitive string and integer
+// attributes of s that adoptExistingConnection compares, addressed under
+// prefix. Lists are walked as configured in d, and their length is compared
+// under their ".#" address.
+func adoptionKeys(d *schema.ResourceData, prefix string, s map[string]*schema.Schema, keys map[string]*schema.Schema) {
+	for k, v := range s {
+		if !(v.Required || v.Optional) || v.Sensitive {
+			continue
+		}
+
+		key := prefix + k
+		switch v.Type {
+		case schema.TypeString, schema.TypeInt:
+			keys[key] = v
+		case schema.TypeList:
+			keys[key+".#"] = &schema.Schema{Type: schema.TypeInt}
+			for i := 0; i < d.Get(key+".#").(int); i++ {
+				elemKey := fmt.Sprintf("%s.%d", key, i)
+				switch elem := v.Elem.(type) {
+				case *schema.Resource:
+					adoptionKeys(d, elemKey+".", elem.Schema, keys)
+				case *schema.Schema:
+					if !elem.Sensitive && (elem.Type == schema.TypeString || elem.Type == schema.TypeInt) {
+						keys[elemKey] = elem
+					}
+				}
+			}
+		}
+	}
+}
+
+// checkDuplicatePortVLANs returns an error if two blocks of the list key use
+// the same vlan of the same port_id. Blocks whose port or VLAN is unknown are
+// skipped.
+func checkDuplicatePortVLANs(d *schema.ResourceDiff, key string) error {
+	seen := make(map[string]int)
+	for i := range d.Get(key).([]interface{}) {
+		portKey := fmt.Sprintf("%s.%d.port_id", key, i)
+		vlanKey := fmt.Sprintf("%s.%d.vlan", key, i)
+		if !d.NewValueKnown(portKey) || !d.NewValueKnown(vlanKey) {
+			continue
+		}
+
+		portID := d.Get(portKey).(string)
+		vlan := d.Get(vlanKey).(int)
+		k := fmt.Sprintf("%s/%d", portID, vlan)
+		if j, ok := seen[k]; ok {
+			return fmt.Errorf("%s.%d and %s.%d both use VLAN %d of port %s", key, j, key, i, vlan, portID)
+		}
+		seen[k] = i
+	}
+
+	return nil
+}
+
+// timeLayouts lists the timestamp formats accepted by parseTime, in order.
+var timeLayouts = []string{
+	time.RFC3339,
+	time.RFC3339Nano,
+	"2006-01-02T15:04:05Z0700",
+}
+
+// parseTime parses a timestamp using the first matching layout of timeLayouts.
+func parseTime(v string) (time.Time, error) {
+	var err error
+	for _, layout := range timeLayouts {
+		var t time.Time
+		if t, err = time.Parse(layout, v); err == nil {
+			return t, nil
+		}
+	}
+
+	return time.Time{}, err
+}
+
+// readCreatedAt sets created_at, while it is unset, to the time FIC received
+// the request that created the connection. It is called once by Create, so
+// that Read does not list the operation history of the tenant. The time is
+// taken from the Create operation of the connection and stored in RFC 3339
+// in UTC, so a change of the time format of the API cannot change it. When
+// the lookup fails or the history holds no Create operation, created_at is
+// left empty and a warning is logged.
+func readCreatedAt(d *schema.ResourceData, meta interface{}) {
+	if v, _ := d.Get("created_at").(string); v != "" {
+		return
+	}
+
+	config := meta.(*Config)
+	client, err := config.eriV1Client(GetRegion(d, config))
+	if err != nil {
+		log.Printf("[WARN] Unable to look up the creation time of connection %s: %s", d.Id(), err)
+		return
+	}
+
+	createdAt, err := connectionCreatedAt(client, d.Id())
+	if err != nil {
+		log.Printf("[WARN] Unable to look up the creation time of connection %s: %s", d.Id(), err)
+		return
+	}
+
+	if createdAt == "" {
+		log.Printf("[WARN] No Create operation of connection %s in the operation history, leaving created_at empty", d.Id())
+		return
+	}
+
+	d.Set("created_at", createdAt)
+}
+
+// connectionCreatedAt returns the reception time of the Create operation of
+// the resource id in RFC 3339, or "" if there is no such operation.
+func connectionCreatedAt(client *fic.ServiceClient, id string) (string, error) {
+	var createdAt string
+	err := operations.List(client, nil).EachPage(func(page pagination.Page) (bool, error) {
+		ops, err := operations.ExtractOperations(page)
+		if err != nil {
+			return false, err
+		}
+
+		for _, op := range ops {
+			if op.ResourceID != id || op.RequestType != "Create" {
+				continue
+			}
+
+			t, err := parseTime(op.ReceptionTime)
+			if err != nil {
+				return false, fmt.Errorf("reception time of operation %s: %s", op.ID, err)
+			}
+			createdAt = t.UTC().Format(time.RFC3339)
+			return false, nil
+		}
+		return true, nil
+	})
+
+	return createdAt, err
+}
+
+// parseCompositeID splits an ID made of n values joined with "/", such as a
+// router ID and a VLAN, into its parts.
+func parseCompositeID(id string, n int) ([]string, error) {
+	parts := strings.Split(id, "/")
+	if len(parts) != n {
+		return nil, fmt.Errorf("invalid ID %q: expected %d parts separated by /", id, n)
+	}
+
+	for _, p := range parts {
+		if p == "" {
+			return nil, fmt.Errorf("invalid ID %q: empty part", id)
+		}
+	}
+
+	return parts, nil
+}
+
+// importRouterToPortConnection imports a connection from a router to ports
+// by its ID, or by the composite ID <router_id>/<vlan> of the router and the
+// VLAN of one of its destinations. The connection ID is kept as the ID.
+func importRouterToPortConnection(d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+	if !strings.Contains(d.Id(), "/") {
+		return []*schema.ResourceData{d}, nil
+	}
+
+	parts, err := parseCompositeID(d.Id(), 2)
+	if err != nil {
+		return nil, err
+	}
+
+	vlan, err := strconv.Atoi(parts[1])
+	if err != nil {
+		return nil, fmt.Errorf("invalid VLAN %q in ID %q", parts[1], d.Id())
+	}
+
+	config := meta.(*Config)
+	client, err := config.eriV1Client(GetRegion(d, config))
+	if err != nil {
+		return nil, fmt.Errorf("Error creating FIC ERI client: %s", err)
+	}
+
+	conn, err := findRouterToPortConnection(client, parts[0], vlan)
+	if err != nil {
+		return nil, fmt.Errorf("unable to import connection %s: %s", d.Id(), err)
+	}
+
+	d.SetId(conn.ID)
+	return []*schema.ResourceData{d}, nil
+}
+
 func suppressEquivilentTimeDiffs(k, old, new string, d *schema.ResourceData) bool {
-	oldTime, err := time.Parse(time.RFC3339, old)
+	oldTime, err := parseTime(old)
 	if err != nil {
 		return false
 	}
 
-	newTime, err := time.Parse(time.RFC3339, new)
+	newTime, err := parseTime(new)
 	if err != nil {
 		return false
 	}
@@ -195,3 +923,61 @@ func networkV2AttributesTags(d *schema.ResourceData) (tags []string) {
 func repeatedString(baseString string, repeatCount int) string {
 	return strings.Repeat(baseString, repeatCount)
 }
+
+// rawJSONSensitiveKeys lists the keys of API responses whose values are
+// replaced before a response is stored in raw_json.
+var rawJSONSensitiveKeys = []string{
+	"eclApiKey", "eclApiSecretKey", "pairingKey", "password", "serviceKey", "sharedKey", "token",
+}
+
+// scrubRawJSON replaces the values of rawJSONSensitiveKeys in a decoded JSON
+// document, at any depth. Objects and arrays under a sensitive key are
+// scrubbed in turn rather than replaced, so that a Keystone token object
+// keeps its non-secret fields.
+func scrubRawJSON(v interface{}) {
+	switch v := v.(type) {
+	case map[string]interface{}:
+		for k, val := range v {
+			switch val.(type) {
+			case map[string]interface{}, []interface{}:
+				scrubRawJSON(val)
+				continue
+			}
+
+			if com.IsSliceContainsStr(rawJSONSensitiveKeys, k) {
+				v[k] = "***"
+			}
+		}
+	case []interface{}:
+		for _, val := range v {
+			scrubRawJSON(val)
+		}
+	}
+}
+
+// setRawJSON stores the API representation of a resource in the raw_json
+// attribute, with credentials scrubbed, if the provider has expose_raw_json
+// enabled. Otherwise raw_json is left empty.
+func setRawJSON(d *schema.ResourceData, config *Config, v interface{}) error {
+	if !config.ExposeRawJSON {
+		return d.Set("raw_json", "")
+	}
+
+	b, err := json.Marshal(v)
+	if err != nil {
+		return fmt.Errorf("Error marshaling raw_json: %s", err)
+	}
+
+	var doc interface{}
+	if err := json.Unmarshal(b, &doc); err != nil {
+		return fmt.Errorf("Error marshaling raw_json: %s", err)
+	}
+	scrubRawJSON(doc)
+
+	b, err = json.Marshal(doc)
+	if err != nil {
+		return fmt.Errorf("Error marshaling raw_json: %s", err)
+	}
+
+	return d.Set("raw_json", string(b))
+}
@@ -0,0 +1,521 @@
+package fic
+
+import (
+	"context"
+	"errors"
+	"net"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+	"syscall"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"github.com/nttcom/go-fic"
+)
+
+func TestRedactHeadersMixedCase(t *testing.T) {
+	headers := http.Header{
+		"X-Auth-Token":    []string{"secret"},
+		"x-subject-token": []string{"secret"},
+		"Content-Type":    []string{"application/json"},
+	}
+
+	expected := "Content-Type: application/json\nX-Auth-Token: ***\nx-subject-token: ***"
+	if actual := FormatHeaders(headers, "\n"); actual != expected {
+		t.Fatalf("expected %q, got %q", expected, actual)
+	}
+}
+
+func TestAddRedactHeaders(t *testing.T) {
+	original := REDACT_HEADERS
+	defer func() { REDACT_HEADERS = original }()
+
+	REDACT_HEADERS = append([]string{}, original...)
+	AddRedactHeaders("Proxy-Authorization", "X-Auth-Token", "")
+
+	if len(REDACT_HEADERS) != len(original)+1 {
+		t.Fatalf("expected %d redact headers, got %v", len(original)+1, REDACT_HEADERS)
+	}
+
+	headers := http.Header{
+		"Proxy-Authorization": []string{"Basic dXNlcjpwYXNz"},
+		"Accept":              []string{"application/json"},
+	}
+
+	expected := "Accept: application/json, Proxy-Authorization: ***"
+	if actual := FormatHeaders(headers, ", "); actual != expected {
+		t.Fatalf("expected %q, got %q", expected, actual)
+	}
+}
+
+func TestGetRegion(t *testing.T) {
+	regionSchema := map[string]*schema.Schema{
+		"region": {
+			Type:     schema.TypeString,
+			Optional: true,
+		},
+	}
+
+	cases := []struct {
+		name     string
+		resource string
+		ficEnv   string
+		osEnv    string
+		config   string
+		expected string
+	}{
+		{"resource wins", "resource", "fic", "os", "config", "resource"},
+		{"provider config wins over FIC_REGION", "", "fic", "os", "config", "config"},
+		{"provider config", "", "", "", "config", "config"},
+		{"unset", "", "", "", "", ""},
+	}
+
+	for _, env := range []string{"FIC_REGION", "OS_REGION_NAME"} {
+		original, ok := os.LookupEnv(env)
+		defer func(env, original string, ok bool) {
+			if ok {
+				os.Setenv(env, original)
+			} else {
+				os.Unsetenv(env)
+			}
+		}(env, original, ok)
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			os.Setenv("FIC_REGION", tc.ficEnv)
+			os.Setenv("OS_REGION_NAME", tc.osEnv)
+
+			raw := map[string]interface{}{}
+			if tc.resource != "" {
+				raw["region"] = tc.resource
+			}
+			d := schema.TestResourceDataRaw(t, regionSchema, raw)
+
+			if actual := GetRegion(d, &Config{Region: tc.config}); actual != tc.expected {
+				t.Fatalf("expected %q, got %q", tc.expected, actual)
+			}
+		})
+	}
+}
+
+func TestCheckDeletedWithFlag(t *testing.T) {
+	d := schema.TestResourceDataRaw(t, map[string]*schema.Schema{}, map[string]interface{}{})
+
+	d.SetId("id")
+	deleted, err := CheckDeletedWithFlag(d, fic.ErrDefault404{}, "port")
+	if !deleted || err != nil {
+		t.Fatalf("expected deleted with no error, got %t, %v", deleted, err)
+	}
+	if d.Id() != "" {
+		t.Fatalf("expected the ID to be cleared, got %q", d.Id())
+	}
+
+	d.SetId("id")
+	deleted, err = CheckDeletedWithFlag(d, fic.ErrDefault500{}, "port")
+	if deleted || err == nil {
+		t.Fatalf("expected an error, got %t, %v", deleted, err)
+	}
+	if !strings.HasPrefix(err.Error(), "port: ") {
+		t.Fatalf("expected the error to be wrapped, got %q", err)
+	}
+	if d.Id() != "id" {
+		t.Fatalf("expected the ID to be kept, got %q", d.Id())
+	}
+}
+
+func TestFormatFICError(t *testing.T) {
+	body := []byte(`{"connection": {"id": "F030123456789", "operationId": "8d49e2ab41a54598aec02c0f198ab0e3"}}`)
+	cases := []struct {
+		err      error
+		expected string
+	}{
+		{
+			fic.ErrUnexpectedResponseCode{Actual: 409, Body: body},
+			"Error updating connection (operation ID: 8d49e2ab41a54598aec02c0f198ab0e3): ",
+		},
+		{
+			fic.ErrDefault500{ErrUnexpectedResponseCode: fic.ErrUnexpectedResponseCode{Actual: 500, Body: []byte(`{"operationId": "d981d661a4be48bca8b748a84b0325c4"}`)}},
+			"Error updating connection (operation ID: d981d661a4be48bca8b748a84b0325c4): ",
+		},
+		{
+			fic.ErrDefault400{ErrUnexpectedResponseCode: fic.ErrUnexpectedResponseCode{Actual: 400, Body: []byte("Bad Request")}},
+			"Error updating connection: Bad request",
+		},
+	}
+
+	for _, tc := range cases {
+		err := formatFICError(tc.err, "Error updating connection")
+		if !strings.HasPrefix(err.Error(), tc.expected) {
+			t.Errorf("%T: expected prefix %q, got %q", tc.err, tc.expected, err)
+		}
+		if u := errors.Unwrap(err); u == nil || u.Error() != tc.err.Error() {
+			t.Errorf("%T: expected the original error to be wrapped, got %v", tc.err, u)
+		}
+	}
+}
+
+func TestFormatFICErrorMultiple(t *testing.T) {
+	cases := [][]byte{
+		[]byte(`{"errors": [{"field": "name", "code": "ERI-01-0008", "message": "name is too long"}, {"field": "bandwidth", "code": "ERI-01-0012", "message": "bandwidth is not supported"}, {"message": "the router is in use"}]}`),
+		[]byte(`{"error": {"errors": [{"field": "name", "code": "ERI-01-0008", "message": "name is too long"}, {"field": "bandwidth", "code": "ERI-01-0012", "message": "bandwidth is not supported"}, {"message": "the router is in use"}]}}`),
+	}
+	expected := []string{
+		"\n  - name (ERI-01-0008) name is too long",
+		"\n  - bandwidth (ERI-01-0012) bandwidth is not supported",
+		"\n  - the router is in use",
+	}
+
+	for _, body := range cases {
+		err := formatFICError(fic.ErrDefault400{ErrUnexpectedResponseCode: fic.ErrUnexpectedResponseCode{Actual: 400, Body: body}}, "Error creating connection")
+		if !strings.HasPrefix(err.Error(), "Error creating connection: ") {
+			t.Errorf("unexpected error prefix: %q", err)
+		}
+		for _, e := range expected {
+			if !strings.Contains(err.Error(), e) {
+				t.Errorf("expected %q in %q", e, err)
+			}
+		}
+		if u := errors.Unwrap(err); u == nil {
+			t.Errorf("expected the original error to be wrapped")
+		}
+	}
+}
+
+func TestCheckForRetryableError(t *testing.T) {
+	refused := &url.Error{
+		Op:  "Get",
+		URL: "https://api.example.com/v1/ports",
+		Err: &net.OpError{Op: "dial", Net: "tcp", Err: os.NewSyscallError("connect", syscall.ECONNREFUSED)},
+	}
+	reset := &net.OpError{Op: "read", Net: "tcp", Err: os.NewSyscallError("read", syscall.ECONNRESET)}
+	timeout := &url.Error{Op: "Get", URL: "https://api.example.com/v1/ports", Err: &net.OpError{Op: "dial", Net: "tcp", Err: &net.DNSError{IsTimeout: true}}}
+	cancelled := &url.Error{Op: "Get", URL: "https://api.example.com/v1/ports", Err: context.Canceled}
+
+	cases := []struct {
+		err       error
+		retryable bool
+	}{
+		{refused, true},
+		{reset, true},
+		{timeout, true},
+		{&net.DNSError{Err: "server misbehaving", IsTemporary: true}, true},
+		{&net.DNSError{Err: "no such host", IsNotFound: true}, false},
+		{cancelled, false},
+		{context.Canceled, false},
+		{&net.OpError{Op: "dial", Net: "tcp", Err: errors.New("permission denied")}, false},
+		{fic.ErrUnexpectedResponseCode{Actual: 429}, true},
+		{fic.ErrDefault429{ErrUnexpectedResponseCode: fic.ErrUnexpectedResponseCode{Actual: 429}}, true},
+		{fic.ErrUnexpectedResponseCode{Actual: 409}, true},
+		{fic.ErrDefault409{ErrUnexpectedResponseCode: fic.ErrUnexpectedResponseCode{Actual: 409}}, true},
+		{fic.ErrUnexpectedResponseCode{Actual: 503}, true},
+		{fic.ErrDefault503{ErrUnexpectedResponseCode: fic.ErrUnexpectedResponseCode{Actual: 503}}, true},
+		{fic.ErrDefault500{}, true},
+		{fic.ErrTimeOut{}, true},
+		{&fic.ErrTimeOut{}, true},
+		{&url.Error{Op: "Delete", URL: "https://api.example.com/v1/routers/F020123456789", Err: fic.ErrTimeOut{}}, true},
+		{fic.ErrUnexpectedResponseCode{Actual: 400}, false},
+		{fic.ErrDefault404{}, false},
+	}
+
+	for _, tc := range cases {
+		rerr := checkForRetryableError(tc.err)
+		if rerr.Retryable != tc.retryable {
+			t.Errorf("%T (%v): expected retryable=%t, got %t", tc.err, tc.err, tc.retryable, rerr.Retryable)
+		}
+	}
+}
+
+func TestMapValueSpecs(t *testing.T) {
+	m, err := mapValueSpecs(map[string]interface{}{"foo": "bar"})
+	if err != nil || m["foo"] != "bar" {
+		t.Fatalf("unexpected result: %v, %v", m, err)
+	}
+
+	_, err = mapValueSpecs(map[string]interface{}{"count": 42})
+	if err == nil {
+		t.Fatal("expected an error for a non-string value")
+	}
+
+	expected := "value_specs.count must be a string, got int"
+	if err.Error() != expected {
+		t.Fatalf("expected %q, got %q", expected, err)
+	}
+}
+
+func TestAddValueSpecs(t *testing.T) {
+	body, err := AddValueSpecs(map[string]interface{}{
+		"name": "port",
+		"value_specs": map[string]interface{}{
+			"count":   42,
+			"enabled": true,
+		},
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	if _, ok := body["value_specs"]; ok {
+		t.Fatal("expected value_specs to be removed")
+	}
+
+	if body["count"] != 42 || body["enabled"] != true || body["name"] != "port" {
+		t.Fatalf("unexpected body: %v", body)
+	}
+}
+
+func TestAddValueSpecsCollision(t *testing.T) {
+	_, err := AddValueSpecs(map[string]interface{}{
+		"name":      "connection",
+		"bandwidth": "10M",
+		"value_specs": map[string]string{
+			"bandwidth": "1G",
+			"name":      "other",
+			"extra":     "value",
+		},
+	})
+	if err == nil {
+		t.Fatal("expected an error for a colliding key")
+	}
+
+	expected := "value_specs.bandwidth conflicts with a field that is already set, use the argument instead"
+	if err.Error() != expected {
+		t.Fatalf("expected %q, got %q", expected, err)
+	}
+}
+
+func TestFlattenASPathPrepend(t *testing.T) {
+	var unset interface{}
+	var fromJSON interface{} = float64(4)
+	var fromInt interface{} = 2
+
+	cases := []struct {
+		in       *interface{}
+		expected string
+	}{
+		{nil, "OFF"},
+		{&unset, "OFF"},
+		{&fromJSON, "4"},
+		{&fromInt, "2"},
+	}
+
+	for _, tc := range cases {
+		if actual := flattenASPathPrepend(tc.in); actual != tc.expected {
+			t.Errorf("expected %q, got %q", tc.expected, actual)
+		}
+	}
+}
+
+func TestSuppressEquivilentTimeDiffs(t *testing.T) {
+	cases := []struct {
+		old      string
+		new      string
+		suppress bool
+	}{
+		{"2020-01-02T03:04:05Z", "2020-01-02T12:04:05+09:00", true},
+		{"2020-01-02T03:04:05Z", "2020-01-02T03:04:05.000Z", true},
+		{"2020-01-02T03:04:05.123456789Z", "2020-01-02T12:04:05.123456789+09:00", true},
+		{"2020-01-02T03:04:05Z", "2020-01-02T12:04:05+0900", true},
+		{"2020-01-02T12:04:05+0900", "2020-01-02T03:04:05.000+00:00", true},
+		{"2020-01-02T03:04:05Z", "2020-01-02T03:04:06Z", false},
+		{"2020-01-02T03:04:05Z", "2020-01-02T03:04:05+0900", false},
+		{"2020-01-02T03:04:05Z", "not a time", false},
+		{"", "2020-01-02T03:04:05Z", false},
+	}
+
+	for _, tc := range cases {
+		if actual := suppressEquivilentTimeDiffs("", tc.old, tc.new, nil); actual != tc.suppress {
+			t.Errorf("%q vs %q: expected suppress=%t, got %t", tc.old, tc.new, tc.suppress, actual)
+		}
+	}
+}
+
+func TestGetBoolPtr(t *testing.T) {
+	boolSchema := map[string]*schema.Schema{
+		"redundant": {
+			Type:     schema.TypeBool,
+			Optional: true,
+		},
+	}
+
+	d := schema.TestResourceDataRaw(t, boolSchema, map[string]interface{}{})
+	if v := GetBoolPtr(d, "redundant"); v != nil {
+		t.Fatalf("expected nil for an unset attribute, got %t", *v)
+	}
+
+	for _, expected := range []bool{false, true} {
+		d := schema.TestResourceDataRaw(t, boolSchema, map[string]interface{}{
+			"redundant": expected,
+		})
+
+		v := GetBoolPtr(d, "redundant")
+		if v == nil || *v != expected {
+			t.Fatalf("expected %t, got %v", expected, v)
+		}
+	}
+}
+
+func TestSuppressEquivalentBandwidthDiff(t *testing.T) {
+	cases := []struct {
+		old      string
+		new      string
+		suppress bool
+	}{
+		{"10M", "10M", true},
+		{"10m", "10M", true},
+		{"10Mbps", "10M", true},
+		{" 100 M ", "100M", true},
+		{"1G", "1000M", true},
+		{"1gbps", "1G", true},
+		{"10G", "10000Mbps", true},
+		{"10M", "100M", false},
+		{"1G", "10G", false},
+		{"1G", "100M", false},
+		{"", "10M", false},
+		{"10", "10M", false},
+		{"fast", "10M", false},
+	}
+
+	for _, tc := range cases {
+		if actual := suppressEquivalentBandwidthDiff("", tc.old, tc.new, nil); actual != tc.suppress {
+			t.Errorf("%q vs %q: expected suppress=%t, got %t", tc.old, tc.new, tc.suppress, actual)
+		}
+	}
+}
+
+func TestSuppressEquivalentCIDRDiff(t *testing.T) {
+	cases := []struct {
+		old      string
+		new      string
+		suppress bool
+	}{
+		{"10.0.0.0/24", "10.0.0.0/24", true},
+		{"10.0.0.0/24", "10.0.0.1/24", true},
+		{"10.0.0.0/8", "010.000.000.000/8", true},
+		{"192.168.1.0/24", "192.168.001.255/24", true},
+		{"10.0.0.0/30", " 10.0.0.0/30 ", true},
+		{"2001:db8::/32", "2001:0db8::1/32", true},
+		{"10.0.0.0/24", "10.0.0.0/25", false},
+		{"10.0.0.0/24", "10.0.1.0/24", false},
+		{"", "10.0.0.0/24", false},
+		{"10.0.0.0", "10.0.0.0/32", false},
+		{"10.0.0.256/24", "10.0.0.0/24", false},
+	}
+
+	for _, tc := range cases {
+		if actual := suppressEquivalentCIDRDiff("", tc.old, tc.new, nil); actual != tc.suppress {
+			t.Errorf("%q vs %q: expected suppress=%t, got %t", tc.old, tc.new, tc.suppress, actual)
+		}
+	}
+}
+
+func TestGetOptionalBlock(t *testing.T) {
+	blockSchema := map[string]*schema.Schema{
+		"block": {
+			Type:     schema.TypeList,
+			Optional: true,
+			MaxItems: 1,
+			Elem: &schema.Resource{
+				Schema: map[string]*schema.Schema{
+					"name": {Type: schema.TypeString, Optional: true},
+				},
+			},
+		},
+	}
+
+	d := schema.TestResourceDataRaw(t, blockSchema, map[string]interface{}{
+		"block": []interface{}{
+			map[string]interface{}{"name": "primary"},
+		},
+	})
+	m, ok := getOptionalBlock(d, "block")
+	if !ok || m["name"] != "primary" {
+		t.Fatalf("expected the block content, got %v, %t", m, ok)
+	}
+
+	d = schema.TestResourceDataRaw(t, blockSchema, map[string]interface{}{})
+	if m, ok := getOptionalBlock(d, "block"); ok {
+		t.Fatalf("expected no block, got %v", m)
+	}
+
+	if m, ok := singleBlock([]interface{}{nil}); ok {
+		t.Fatalf("expected no content for an empty block, got %v", m)
+	}
+
+	if source := expandSource(nil); source.RouterID != "" {
+		t.Fatalf("expected an empty source, got %+v", source)
+	}
+}
+
+func TestSuppressCaseInsensitiveDiff(t *testing.T) {
+	cases := []struct {
+		old      string
+		new      string
+		suppress bool
+	}{
+		{"JPEAST", "JPEAST", true},
+		{"JPEAST", "jpeast", true},
+		{"NTTComTokyo(NW1)", "nttcomtokyo(nw1)", true},
+		{"JPEAST", "JPWEST", false},
+		{"Tokyo", "Osaka", false},
+		{"", "JPEAST", false},
+	}
+
+	for _, tc := range cases {
+		if actual := suppressCaseInsensitiveDiff("", tc.old, tc.new, nil); actual != tc.suppress {
+			t.Errorf("%q vs %q: expected suppress=%t, got %t", tc.old, tc.new, tc.suppress, actual)
+		}
+	}
+}
+
+func TestSetRawJSON(t *testing.T) {
+	rawJSONSchema := map[string]*schema.Schema{
+		"raw_json": {Type: schema.TypeString, Computed: true},
+	}
+	v := map[string]interface{}{
+		"id": "F030123456789",
+		"destination": map[string]interface{}{
+			"eclApiKey":       "key",
+			"eclApiSecretKey": "secret",
+			"primary": []interface{}{
+				map[string]interface{}{"sharedKey": "shared", "asn": "65000"},
+			},
+		},
+	}
+
+	d := schema.TestResourceDataRaw(t, rawJSONSchema, map[string]interface{}{})
+	if err := setRawJSON(d, &Config{}, v); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if raw := d.Get("raw_json").(string); raw != "" {
+		t.Fatalf("expected raw_json to be empty by default, got %q", raw)
+	}
+
+	d = schema.TestResourceDataRaw(t, rawJSONSchema, map[string]interface{}{})
+	if err := setRawJSON(d, &Config{ExposeRawJSON: true}, v); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	expected := `{"destination":{"eclApiKey":"***","eclApiSecretKey":"***","primary":[{"asn":"65000","sharedKey":"***"}]},"id":"F030123456789"}`
+	if raw := d.Get("raw_json").(string); raw != expected {
+		t.Fatalf("expected %s, got %s", expected, raw)
+	}
+}
+
+func TestParseCompositeID(t *testing.T) {
+	parts, err := parseCompositeID("F020123456789/1025", 2)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if parts[0] != "F020123456789" || parts[1] != "1025" {
+		t.Fatalf("unexpected parts %v", parts)
+	}
+
+	for _, id := range []string{"F020123456789", "F020123456789/1025/1", "F020123456789/", "/1025"} {
+		if _, err := parseCompositeID(id, 2); err == nil {
+			t.Errorf("expected an error for %q", id)
+		}
+	}
+}
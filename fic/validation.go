@@ -2,9 +2,13 @@ package fic
 
 import (
 	"fmt"
+	"net/url"
+	"regexp"
+	"sort"
 	"strconv"
 
 	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"github.com/unknwon/com"
 )
 
 // IntInSlice returns a SchemaValidateFunc which tests if the provided value
@@ -56,3 +60,176 @@ func ValidateVRID() schema.SchemaValidateFunc {
 		return
 	}
 }
+
+// ValidateValueSpecs returns a SchemaValidateFunc which tests if every value
+// of the provided value_specs map is a string
+func ValidateValueSpecs() schema.SchemaValidateFunc {
+	return func(i interface{}, k string) (s []string, es []error) {
+		m, ok := i.(map[string]interface{})
+		if !ok {
+			es = append(es, fmt.Errorf("expected type of %s to be a map", k))
+			return
+		}
+
+		keys := make([]string, 0, len(m))
+		for key := range m {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+
+		for _, key := range keys {
+			if _, ok := m[key].(string); !ok {
+				es = append(es, fmt.Errorf("expected %s.%s to be a string, got %T", k, key, m[key]))
+			}
+		}
+
+		return
+	}
+}
+
+// reservedASNRanges lists the ASN ranges reserved by IANA (RFC 5398, RFC 6793,
+// RFC 7300) which are accepted but warned about.
+var reservedASNRanges = [][2]uint64{
+	{23456, 23456},
+	{64496, 64511},
+	{65535, 65535},
+	{65536, 65551},
+	{4294967295, 4294967295},
+}
+
+// ValidateASN returns a SchemaValidateFunc which tests if the provided value
+// is a string containing a BGP AS number in the range from 1 to 4294967295
+func ValidateASN() schema.SchemaValidateFunc {
+	return func(i interface{}, k string) (s []string, es []error) {
+		v, ok := i.(string)
+		if !ok {
+			es = append(es, fmt.Errorf("expected type of %s to be a string", k))
+			return
+		}
+
+		asn, err := strconv.ParseUint(v, 10, 64)
+		if err != nil || asn < 1 || asn > 4294967295 {
+			es = append(es, fmt.Errorf("expected %s to be an AS number in the range from 1 to 4294967295, got %q", k, v))
+			return
+		}
+
+		for _, r := range reservedASNRanges {
+			if asn >= r[0] && asn <= r[1] {
+				s = append(s, fmt.Sprintf("%s is set to %d, which is a reserved AS number", k, asn))
+				return
+			}
+		}
+
+		return
+	}
+}
+
+// endpointOverrideServices lists the service names accepted as keys of the
+// endpoint_overrides provider argument.
+var endpointOverrideServices = []string{"eri"}
+
+// ValidateEndpointOverrides returns a SchemaValidateFunc which tests if the
+// keys of the provided map are known service names and every value is an
+// absolute URL
+func ValidateEndpointOverrides() schema.SchemaValidateFunc {
+	return func(i interface{}, k string) (s []string, es []error) {
+		m, ok := i.(map[string]interface{})
+		if !ok {
+			es = append(es, fmt.Errorf("expected type of %s to be a map", k))
+			return
+		}
+
+		keys := make([]string, 0, len(m))
+		for key := range m {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+
+		for _, key := range keys {
+			if !com.IsSliceContainsStr(endpointOverrideServices, key) {
+				es = append(es, fmt.Errorf("expected %s.%s to be one of %v", k, key, endpointOverrideServices))
+				continue
+			}
+
+			v, ok := m[key].(string)
+			if !ok {
+				es = append(es, fmt.Errorf("expected %s.%s to be a string, got %T", k, key, m[key]))
+				continue
+			}
+
+			u, err := url.Parse(v)
+			if err != nil || !u.IsAbs() || u.Host == "" {
+				es = append(es, fmt.Errorf("expected %s.%s to be an absolute URL, got %q", k, key, v))
+			}
+		}
+
+		return
+	}
+}
+
+// ficNameMaxLength is the maximum length of the name of a port, a router or
+// a connection.
+const ficNameMaxLength = 64
+
+// ficNameInvalidChars matches the characters not allowed in the name of a
+// port, a router or a connection.
+var ficNameInvalidChars = regexp.MustCompile(`[^\w&()-]`)
+
+// ValidateFICName returns a SchemaValidateFunc which tests if the provided
+// value is a name of 1 to 64 half-width alphanumeric characters and &()-_
+func ValidateFICName() schema.SchemaValidateFunc {
+	return func(i interface{}, k string) (s []string, es []error) {
+		v, ok := i.(string)
+		if !ok {
+			es = append(es, fmt.Errorf("expected type of %s to be a string", k))
+			return
+		}
+
+		if len(v) < 1 || len(v) > ficNameMaxLength {
+			es = append(es, fmt.Errorf("expected length of %s to be in the range (1 - %d), got %q (%d characters)", k, ficNameMaxLength, v, len(v)))
+		}
+
+		if c := ficNameInvalidChars.FindString(v); c != "" {
+			es = append(es, fmt.Errorf("%s %q contains %q, only half-width alphanumeric characters and &()-_ are allowed", k, v, c))
+		}
+
+		return
+	}
+}
+
+// apiVersionFormat matches an API microversion such as 1.0.
+var apiVersionFormat = regexp.MustCompile(`^\d+\.\d+$`)
+
+// ValidateAPIVersion returns a SchemaValidateFunc which tests if the provided
+// value is empty or an API microversion of the form major.minor
+func ValidateAPIVersion() schema.SchemaValidateFunc {
+	return func(i interface{}, k string) (s []string, es []error) {
+		v, ok := i.(string)
+		if !ok {
+			es = append(es, fmt.Errorf("expected type of %s to be string", k))
+			return
+		}
+
+		if v != "" && !apiVersionFormat.MatchString(v) {
+			es = append(es, fmt.Errorf("expected %s to be of the form major.minor, e.g. 1.0, got %q", k, v))
+		}
+		return
+	}
+}
+
+// ValidateCIDR returns a SchemaValidateFunc which tests if the provided value
+// is a network in CIDR notation, such as 192.168.0.0/24
+func ValidateCIDR() schema.SchemaValidateFunc {
+	return func(i interface{}, k string) (s []string, es []error) {
+		v, ok := i.(string)
+		if !ok {
+			es = append(es, fmt.Errorf("expected type of %s to be string", k))
+			return
+		}
+
+		if _, err := canonicalCIDR(v); err != nil {
+			es = append(es, fmt.Errorf("expected %s to be a network in CIDR notation, e.g. 192.168.0.0/24: %s", k, err))
+		}
+		return
+	}
+}
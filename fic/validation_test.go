@@ -2,6 +2,7 @@ package fic
 
 import (
 	"regexp"
+	"strings"
 	"testing"
 
 	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
@@ -60,3 +61,235 @@ func TestValidationIntInSlice(t *testing.T) {
 		},
 	})
 }
+
+func TestValidationValueSpecs(t *testing.T) {
+	runTestCases(t, []testCase{
+		{
+			val: map[string]interface{}{"foo": "bar"},
+			f:   ValidateValueSpecs(),
+		},
+		{
+			val:         map[string]interface{}{"foo": "bar", "count": 42},
+			f:           ValidateValueSpecs(),
+			expectedErr: regexp.MustCompile("expected [\\w]+\\.count to be a string, got int"),
+		},
+		{
+			val:         "InvalidValue",
+			f:           ValidateValueSpecs(),
+			expectedErr: regexp.MustCompile("expected type of [\\w]+ to be a map"),
+		},
+	})
+}
+
+func TestValidationASN(t *testing.T) {
+	runTestCases(t, []testCase{
+		{
+			val: "1",
+			f:   ValidateASN(),
+		},
+		{
+			val: "65000",
+			f:   ValidateASN(),
+		},
+		{
+			val: "4200000000",
+			f:   ValidateASN(),
+		},
+		{
+			val: "4294967295",
+			f:   ValidateASN(),
+		},
+		{
+			val:         "0",
+			f:           ValidateASN(),
+			expectedErr: regexp.MustCompile("expected [\\w]+ to be an AS number in the range from 1 to 4294967295, got \"0\""),
+		},
+		{
+			val:         "4294967296",
+			f:           ValidateASN(),
+			expectedErr: regexp.MustCompile("expected [\\w]+ to be an AS number in the range from 1 to 4294967295, got \"4294967296\""),
+		},
+		{
+			val:         "7000000000",
+			f:           ValidateASN(),
+			expectedErr: regexp.MustCompile("got \"7000000000\""),
+		},
+		{
+			val:         "AS65000",
+			f:           ValidateASN(),
+			expectedErr: regexp.MustCompile("got \"AS65000\""),
+		},
+		{
+			val:         65000,
+			f:           ValidateASN(),
+			expectedErr: regexp.MustCompile("expected type of [\\w]+ to be a string"),
+		},
+	})
+}
+
+func TestValidationASNReservedWarning(t *testing.T) {
+	cases := map[string]bool{
+		"23456":      true,
+		"64496":      true,
+		"64511":      true,
+		"64512":      false,
+		"65535":      true,
+		"65536":      true,
+		"4294967295": true,
+		"65000":      false,
+	}
+
+	for v, warn := range cases {
+		ws, es := ValidateASN()(v, "asn")
+		if len(es) != 0 {
+			t.Fatalf("%s: unexpected errors: %v", v, es)
+		}
+		if (len(ws) != 0) != warn {
+			t.Fatalf("%s: expected warning=%t, got %v", v, warn, ws)
+		}
+	}
+}
+
+func TestValidationEndpointOverrides(t *testing.T) {
+	runTestCases(t, []testCase{
+		{
+			val: map[string]interface{}{"eri": "http://127.0.0.1:8080/"},
+			f:   ValidateEndpointOverrides(),
+		},
+		{
+			val: map[string]interface{}{"eri": "https://api.example.com/eri"},
+			f:   ValidateEndpointOverrides(),
+		},
+		{
+			val:         map[string]interface{}{"eri": "/eri/"},
+			f:           ValidateEndpointOverrides(),
+			expectedErr: regexp.MustCompile("expected [\\w]+\\.eri to be an absolute URL, got \"/eri/\""),
+		},
+		{
+			val:         map[string]interface{}{"eri": "://bad"},
+			f:           ValidateEndpointOverrides(),
+			expectedErr: regexp.MustCompile("expected [\\w]+\\.eri to be an absolute URL"),
+		},
+		{
+			val:         map[string]interface{}{"compute": "https://api.example.com/"},
+			f:           ValidateEndpointOverrides(),
+			expectedErr: regexp.MustCompile("expected [\\w]+\\.compute to be one of \\[eri\\]"),
+		},
+		{
+			val:         "InvalidValue",
+			f:           ValidateEndpointOverrides(),
+			expectedErr: regexp.MustCompile("expected type of [\\w]+ to be a map"),
+		},
+	})
+}
+
+func TestValidationFICName(t *testing.T) {
+	runTestCases(t, []testCase{
+		{
+			val: "terraform_port-1",
+			f:   ValidateFICName(),
+		},
+		{
+			val: "Connection(A&B)",
+			f:   ValidateFICName(),
+		},
+		{
+			val: strings.Repeat("a", 64),
+			f:   ValidateFICName(),
+		},
+		{
+			val:         strings.Repeat("a", 65),
+			f:           ValidateFICName(),
+			expectedErr: regexp.MustCompile("expected length of [\\w]+ to be in the range \\(1 - 64\\), got \"a+\" \\(65 characters\\)"),
+		},
+		{
+			val:         "",
+			f:           ValidateFICName(),
+			expectedErr: regexp.MustCompile("expected length of [\\w]+ to be in the range \\(1 - 64\\), got \"\" \\(0 characters\\)"),
+		},
+		{
+			val:         "terraform port",
+			f:           ValidateFICName(),
+			expectedErr: regexp.MustCompile("[\\w]+ \"terraform port\" contains \" \", only half-width alphanumeric characters and &\\(\\)-_ are allowed"),
+		},
+		{
+			val:         "ポート",
+			f:           ValidateFICName(),
+			expectedErr: regexp.MustCompile("contains \"ポ\""),
+		},
+		{
+			val:         42,
+			f:           ValidateFICName(),
+			expectedErr: regexp.MustCompile("expected type of [\\w]+ to be a string"),
+		},
+	})
+}
+
+func TestValidationAPIVersion(t *testing.T) {
+	runTestCases(t, []testCase{
+		{
+			val: "",
+			f:   ValidateAPIVersion(),
+		},
+		{
+			val: "1.0",
+			f:   ValidateAPIVersion(),
+		},
+		{
+			val: "2.13",
+			f:   ValidateAPIVersion(),
+		},
+		{
+			val:         "1",
+			f:           ValidateAPIVersion(),
+			expectedErr: regexp.MustCompile("expected [\\w]+ to be of the form major.minor, e.g. 1.0, got \"1\""),
+		},
+		{
+			val:         "v1.0",
+			f:           ValidateAPIVersion(),
+			expectedErr: regexp.MustCompile("expected [\\w]+ to be of the form major.minor, e.g. 1.0, got \"v1.0\""),
+		},
+	})
+}
+
+func TestValidationCIDR(t *testing.T) {
+	runTestCases(t, []testCase{
+		{
+			val: "192.168.0.0/24",
+			f:   ValidateCIDR(),
+		},
+		{
+			val: "192.168.000.001/24",
+			f:   ValidateCIDR(),
+		},
+		{
+			val: "2001:db8::/32",
+			f:   ValidateCIDR(),
+		},
+		{
+			val:         "192.168.0.1",
+			f:           ValidateCIDR(),
+			expectedErr: regexp.MustCompile("expected [\\w]+ to be a network in CIDR notation, e.g. 192.168.0.0/24: \"192.168.0.1\" is not in CIDR notation"),
+		},
+		{
+			val:         "192.168.0.300/24",
+			f:           ValidateCIDR(),
+			expectedErr: regexp.MustCompile("\"192.168.0.300\" is not a valid IPv4 address"),
+		},
+		{
+			val:         "192.168.0.0/33",
+			f:           ValidateCIDR(),
+			expectedErr: regexp.MustCompile("\"192.168.0.0/33\" is not a valid CIDR"),
+		},
+		{
+			val:         "any",
+			f:           ValidateCIDR(),
+			expectedErr: regexp.MustCompile("is not in CIDR notation"),
+		},
+		{
+			val:         42,
+			f:           ValidateCIDR(),
+			expectedErr: regexp.MustCompile("expected type of [\\w]+ to be string"),
+		},
+	})
+}
@@ -0,0 +1,6 @@
+package fic
+
+// providerVersion is the version of the provider sent in the User-Agent
+// header. It is set at build time with
+// -ldflags "-X github.com/nttcom/terraform-provider-fic/fic.providerVersion=<version>".
+var providerVersion = "dev"